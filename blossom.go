@@ -0,0 +1,650 @@
+package sock_pair_in_golang
+
+import "context"
+
+// weightedEdge joins vertices i and j of a graph with the given weight.
+type weightedEdge struct {
+	i, j   int
+	weight int64
+}
+
+// maxWeightMatching returns a maximum cardinality matching of the graph with nVertices vertices
+// and the given edges that, among all maximum cardinality matchings, has the greatest total weight.
+// The matching is returned as mate, where mate[v] is the vertex matched to v or -1.
+//
+// This is Edmonds' blossom algorithm with the primal-dual weight updates described by Galil in
+// "Efficient algorithms for finding maximum matching in graphs", running in O(n³) time. Vertex
+// duals, edge slacks and deltas are all multiplied by two so that only integers are needed.
+// It gives up and returns ctx.Err() if ctx is done between stages.
+func maxWeightMatching(ctx context.Context, nVertices int, edges []weightedEdge) ([]int, error) {
+	m := newBlossomMatching(nVertices, edges)
+	if err := m.run(ctx); err != nil {
+		return nil, err
+	}
+
+	// convert mate from remote endpoints to vertices
+	mate := make([]int, nVertices)
+	for v := range mate {
+		mate[v] = -1
+		if m.mate[v] >= 0 {
+			mate[v] = m.endpoint[m.mate[v]]
+		}
+	}
+	return mate, nil
+}
+
+// blossomMatching holds the state of maxWeightMatching. Edge k has endpoints 2k and 2k+1, where
+// endpoint[p] is the vertex at endpoint p and p^1 is the opposite endpoint of the same edge.
+// Vertices are numbered [0,n) and blossoms [n,2n); a top level vertex is its own blossom.
+type blossomMatching struct {
+	n         int
+	edges     []weightedEdge
+	endpoint  []int
+	neighbend [][]int
+
+	// mate[v] is the remote endpoint of the edge matching v, or -1.
+	mate []int
+	// label[b] is 0 for a free blossom, 1 for an S-blossom and 2 for a T-blossom.
+	label []int
+	// labelend[b] is the remote endpoint of the edge through which b got its label, or -1.
+	labelend         []int
+	inblossom        []int
+	blossomparent    []int
+	blossomchilds    [][]int
+	blossombase      []int
+	blossomendps     [][]int
+	bestedge         []int
+	blossombestedges [][]int
+	unusedblossoms   []int
+	dualvar          []int64
+	allowedge        []bool
+	queue            []int
+}
+
+func newBlossomMatching(n int, edges []weightedEdge) *blossomMatching {
+	m := &blossomMatching{
+		n:                n,
+		edges:            edges,
+		endpoint:         make([]int, 2*len(edges)),
+		neighbend:        make([][]int, n),
+		mate:             make([]int, n),
+		label:            make([]int, 2*n),
+		labelend:         make([]int, 2*n),
+		inblossom:        make([]int, n),
+		blossomparent:    make([]int, 2*n),
+		blossomchilds:    make([][]int, 2*n),
+		blossombase:      make([]int, 2*n),
+		blossomendps:     make([][]int, 2*n),
+		bestedge:         make([]int, 2*n),
+		blossombestedges: make([][]int, 2*n),
+		unusedblossoms:   make([]int, 0, n),
+		dualvar:          make([]int64, 2*n),
+		allowedge:        make([]bool, len(edges)),
+	}
+
+	var maxWeight int64
+	for k, e := range edges {
+		m.endpoint[2*k], m.endpoint[2*k+1] = e.i, e.j
+		m.neighbend[e.i] = append(m.neighbend[e.i], 2*k+1)
+		m.neighbend[e.j] = append(m.neighbend[e.j], 2*k)
+		if e.weight > maxWeight {
+			maxWeight = e.weight
+		}
+	}
+
+	for v := 0; v < n; v++ {
+		m.mate[v] = -1
+		m.inblossom[v] = v
+		m.blossombase[v] = v
+		m.blossombase[n+v] = -1
+		m.dualvar[v] = maxWeight
+		m.unusedblossoms = append(m.unusedblossoms, n+v)
+	}
+	for b := range m.labelend {
+		m.labelend[b] = -1
+		m.blossomparent[b] = -1
+		m.bestedge[b] = -1
+	}
+	return m
+}
+
+// slack returns twice the slack of edge k.
+func (m *blossomMatching) slack(k int) int64 {
+	e := m.edges[k]
+	return m.dualvar[e.i] + m.dualvar[e.j] - 2*e.weight
+}
+
+// leaves returns the vertices contained in blossom b.
+func (m *blossomMatching) leaves(b int) []int {
+	if b < m.n {
+		return []int{b}
+	}
+	leaves := make([]int, 0)
+	for _, t := range m.blossomchilds[b] {
+		leaves = append(leaves, m.leaves(t)...)
+	}
+	return leaves
+}
+
+// assignLabel labels the top level blossom containing vertex w with t, reached through endpoint p.
+func (m *blossomMatching) assignLabel(w, t, p int) {
+	b := m.inblossom[w]
+	m.label[w], m.label[b] = t, t
+	m.labelend[w], m.labelend[b] = p, p
+	m.bestedge[w], m.bestedge[b] = -1, -1
+	if t == 1 {
+		// b became an S-blossom, so its vertices need scanning
+		m.queue = append(m.queue, m.leaves(b)...)
+	} else if t == 2 {
+		// b became a T-blossom, so its mate becomes an S-blossom
+		base := m.blossombase[b]
+		m.assignLabel(m.endpoint[m.mate[base]], 1, m.mate[base]^1)
+	}
+}
+
+// scanBlossom traces back from vertices v and w to discover either a new blossom, returning its
+// base, or an augmenting path, returning -1.
+func (m *blossomMatching) scanBlossom(v, w int) int {
+	path := make([]int, 0)
+	base := -1
+	for v != -1 || w != -1 {
+		// look for a breadcrumb in v's blossom, or put a new one down
+		b := m.inblossom[v]
+		if m.label[b]&4 != 0 {
+			base = m.blossombase[b]
+			break
+		}
+		path = append(path, b)
+		m.label[b] = 5
+
+		if m.labelend[b] == -1 {
+			// the base of blossom b is single, so stop tracing this path
+			v = -1
+		} else {
+			// b is reached through a T-blossom, trace back through it
+			v = m.endpoint[m.labelend[b]]
+			b = m.inblossom[v]
+			v = m.endpoint[m.labelend[b]]
+		}
+
+		// alternate between both paths
+		if w != -1 {
+			v, w = w, v
+		}
+	}
+
+	// remove the breadcrumbs
+	for _, b := range path {
+		m.label[b] = 1
+	}
+	return base
+}
+
+// addBlossom creates a new S-blossom with the given base, formed by edge k between two S-vertices.
+func (m *blossomMatching) addBlossom(base, k int) {
+	v, w := m.edges[k].i, m.edges[k].j
+	bb, bv, bw := m.inblossom[base], m.inblossom[v], m.inblossom[w]
+
+	b := m.unusedblossoms[len(m.unusedblossoms)-1]
+	m.unusedblossoms = m.unusedblossoms[:len(m.unusedblossoms)-1]
+	m.blossombase[b] = base
+	m.blossomparent[b] = -1
+	m.blossomparent[bb] = b
+
+	// trace back from v to the base, collecting sub-blossoms and their connecting endpoints
+	path := make([]int, 0)
+	endps := make([]int, 0)
+	for bv != bb {
+		m.blossomparent[bv] = b
+		path = append(path, bv)
+		endps = append(endps, m.labelend[bv])
+		v = m.endpoint[m.labelend[bv]]
+		bv = m.inblossom[v]
+	}
+	path = append(path, bb)
+	reverseInts(path)
+	reverseInts(endps)
+	endps = append(endps, 2*k)
+
+	// trace back from w to the base
+	for bw != bb {
+		m.blossomparent[bw] = b
+		path = append(path, bw)
+		endps = append(endps, m.labelend[bw]^1)
+		w = m.endpoint[m.labelend[bw]]
+		bw = m.inblossom[w]
+	}
+	m.blossomchilds[b] = path
+	m.blossomendps[b] = endps
+
+	m.label[b] = 1
+	m.labelend[b] = m.labelend[bb]
+	m.dualvar[b] = 0
+
+	for _, v := range m.leaves(b) {
+		if m.label[m.inblossom[v]] == 2 {
+			// a T-vertex inside an S-blossom becomes an S-vertex
+			m.queue = append(m.queue, v)
+		}
+		m.inblossom[v] = b
+	}
+
+	// find the least slack edges from the new blossom to each neighbouring S-blossom
+	bestedgeto := make([]int, 2*m.n)
+	for i := range bestedgeto {
+		bestedgeto[i] = -1
+	}
+	for _, bv := range path {
+		var nblists [][]int
+		if m.blossombestedges[bv] == nil {
+			for _, v := range m.leaves(bv) {
+				nblist := make([]int, 0, len(m.neighbend[v]))
+				for _, p := range m.neighbend[v] {
+					nblist = append(nblist, p/2)
+				}
+				nblists = append(nblists, nblist)
+			}
+		} else {
+			nblists = [][]int{m.blossombestedges[bv]}
+		}
+
+		for _, nblist := range nblists {
+			for _, k := range nblist {
+				i, j := m.edges[k].i, m.edges[k].j
+				if m.inblossom[j] == b {
+					i, j = j, i
+				}
+				bj := m.inblossom[j]
+				if bj != b && m.label[bj] == 1 && (bestedgeto[bj] == -1 || m.slack(k) < m.slack(bestedgeto[bj])) {
+					bestedgeto[bj] = k
+				}
+			}
+		}
+		m.blossombestedges[bv] = nil
+		m.bestedge[bv] = -1
+	}
+
+	m.blossombestedges[b] = make([]int, 0)
+	m.bestedge[b] = -1
+	for _, k := range bestedgeto {
+		if k == -1 {
+			continue
+		}
+		m.blossombestedges[b] = append(m.blossombestedges[b], k)
+		if m.bestedge[b] == -1 || m.slack(k) < m.slack(m.bestedge[b]) {
+			m.bestedge[b] = k
+		}
+	}
+}
+
+// expandBlossom turns the sub-blossoms of b back into top level blossoms.
+func (m *blossomMatching) expandBlossom(b int, endstage bool) {
+	for _, s := range m.blossomchilds[b] {
+		m.blossomparent[s] = -1
+		if s < m.n {
+			m.inblossom[s] = s
+		} else if endstage && m.dualvar[s] == 0 {
+			m.expandBlossom(s, endstage)
+		} else {
+			for _, v := range m.leaves(s) {
+				m.inblossom[v] = s
+			}
+		}
+	}
+
+	// expanding a T-blossom during a stage means relabelling its sub-blossoms
+	if !endstage && m.label[b] == 2 {
+		childs := m.blossomchilds[b]
+		endps := m.blossomendps[b]
+
+		// start at the sub-blossom through which b got its label
+		entrychild := m.inblossom[m.endpoint[m.labelend[b]^1]]
+		j := indexOf(childs, entrychild)
+		jstep, endptrick := -1, 1
+		if j&1 != 0 {
+			// odd start index, so go forward and wrap
+			j -= len(childs)
+			jstep, endptrick = 1, 0
+		}
+
+		// move along the blossom until we get to the base
+		p := m.labelend[b]
+		for j != 0 {
+			m.label[m.endpoint[p^1]] = 0
+			m.label[m.endpoint[at(endps, j-endptrick)^endptrick^1]] = 0
+			m.assignLabel(m.endpoint[p^1], 2, p)
+			m.allowedge[at(endps, j-endptrick)/2] = true
+			j += jstep
+			p = at(endps, j-endptrick) ^ endptrick
+			m.allowedge[p/2] = true
+			j += jstep
+		}
+
+		// relabel the base T-sub-blossom without stepping through to its mate
+		bv := at(childs, j)
+		m.label[m.endpoint[p^1]], m.label[bv] = 2, 2
+		m.labelend[m.endpoint[p^1]], m.labelend[bv] = p, p
+		m.bestedge[bv] = -1
+
+		// continue along the blossom until we get back to entrychild
+		j += jstep
+		for at(childs, j) != entrychild {
+			bv := at(childs, j)
+			if m.label[bv] == 1 {
+				// this sub-blossom already got label S through one of its neighbours
+				j += jstep
+				continue
+			}
+
+			// label the sub-blossom T if a neighbouring S-vertex outside b can reach it
+			reached := -1
+			for _, v := range m.leaves(bv) {
+				if m.label[v] != 0 {
+					reached = v
+					break
+				}
+			}
+			if reached >= 0 {
+				m.label[reached] = 0
+				m.label[m.endpoint[m.mate[m.blossombase[bv]]]] = 0
+				m.assignLabel(reached, 2, m.labelend[reached])
+			}
+			j += jstep
+		}
+	}
+
+	// recycle the blossom
+	m.label[b], m.labelend[b] = -1, -1
+	m.blossomchilds[b], m.blossomendps[b] = nil, nil
+	m.blossombase[b] = -1
+	m.blossombestedges[b] = nil
+	m.bestedge[b] = -1
+	m.unusedblossoms = append(m.unusedblossoms, b)
+}
+
+// augmentBlossom swaps the matched and unmatched edges along the even path through blossom b
+// from vertex v to its base, making v the new base.
+func (m *blossomMatching) augmentBlossom(b, v int) {
+	// bubble up from v to an immediate sub-blossom of b
+	t := v
+	for m.blossomparent[t] != b {
+		t = m.blossomparent[t]
+	}
+	if t >= m.n {
+		m.augmentBlossom(t, v)
+	}
+
+	childs := m.blossomchilds[b]
+	endps := m.blossomendps[b]
+	i := indexOf(childs, t)
+	j := i
+	jstep, endptrick := -1, 1
+	if i&1 != 0 {
+		// odd start index, so go forward and wrap
+		j -= len(childs)
+		jstep, endptrick = 1, 0
+	}
+
+	// move along the blossom until we get to the base
+	for j != 0 {
+		j += jstep
+		t = at(childs, j)
+		p := at(endps, j-endptrick) ^ endptrick
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p])
+		}
+		j += jstep
+		t = at(childs, j)
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p^1])
+		}
+		m.mate[m.endpoint[p]] = p ^ 1
+		m.mate[m.endpoint[p^1]] = p
+	}
+
+	// rotate the sub-blossoms to put the new base first
+	m.blossomchilds[b] = append(append(make([]int, 0, len(childs)), childs[i:]...), childs[:i]...)
+	m.blossomendps[b] = append(append(make([]int, 0, len(endps)), endps[i:]...), endps[:i]...)
+	m.blossombase[b] = m.blossombase[m.blossomchilds[b][0]]
+}
+
+// augmentMatching swaps the matched and unmatched edges along the augmenting path through edge k.
+func (m *blossomMatching) augmentMatching(k int) {
+	e := m.edges[k]
+	for _, sp := range [2][2]int{{e.i, 2*k + 1}, {e.j, 2 * k}} {
+		s, p := sp[0], sp[1]
+		for {
+			bs := m.inblossom[s]
+			if bs >= m.n {
+				m.augmentBlossom(bs, s)
+			}
+			m.mate[s] = p
+
+			if m.labelend[bs] == -1 {
+				// reached a single vertex
+				break
+			}
+			t := m.endpoint[m.labelend[bs]]
+			bt := m.inblossom[t]
+			s = m.endpoint[m.labelend[bt]]
+			j := m.endpoint[m.labelend[bt]^1]
+			if bt >= m.n {
+				m.augmentBlossom(bt, j)
+			}
+			m.mate[j] = m.labelend[bt]
+			p = m.labelend[bt] ^ 1
+		}
+	}
+}
+
+// run finds the matching, one augmenting path per stage.
+func (m *blossomMatching) run(ctx context.Context) error {
+	for stage := 0; stage < m.n; stage++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for b := range m.label {
+			m.label[b] = 0
+			m.bestedge[b] = -1
+		}
+		for b := m.n; b < 2*m.n; b++ {
+			m.blossombestedges[b] = nil
+		}
+		for k := range m.allowedge {
+			m.allowedge[k] = false
+		}
+		m.queue = m.queue[:0]
+
+		// label single vertices S
+		for v := 0; v < m.n; v++ {
+			if m.mate[v] == -1 && m.label[m.inblossom[v]] == 0 {
+				m.assignLabel(v, 1, -1)
+			}
+		}
+
+		if !m.substages() {
+			// no augmenting path remains, so the matching is optimal
+			return nil
+		}
+
+		// expand the S-blossoms whose dual has dropped to zero
+		for b := m.n; b < 2*m.n; b++ {
+			if m.blossomparent[b] == -1 && m.blossombase[b] >= 0 && m.label[b] == 1 && m.dualvar[b] == 0 {
+				m.expandBlossom(b, true)
+			}
+		}
+	}
+	return nil
+}
+
+// substages searches for an augmenting path, adjusting the dual variables whenever the search
+// runs out of allowable edges. It reports whether the matching was augmented.
+func (m *blossomMatching) substages() bool {
+	for {
+		if m.scan() {
+			return true
+		}
+
+		// there is no augmenting path under these constraints, so find the smallest change to
+		// the dual variables that will allow another edge or expand a blossom
+		deltatype := -1
+		var delta int64
+		deltaedge, deltablossom := -1, -1
+
+		// delta2: the least slack edge between an S-vertex and a free vertex
+		for v := 0; v < m.n; v++ {
+			if m.label[m.inblossom[v]] == 0 && m.bestedge[v] != -1 {
+				if d := m.slack(m.bestedge[v]); deltatype == -1 || d < delta {
+					delta, deltatype, deltaedge = d, 2, m.bestedge[v]
+				}
+			}
+		}
+
+		// delta3: half the least slack edge between two S-blossoms
+		for b := 0; b < 2*m.n; b++ {
+			if m.blossomparent[b] == -1 && m.label[b] == 1 && m.bestedge[b] != -1 {
+				if d := m.slack(m.bestedge[b]) / 2; deltatype == -1 || d < delta {
+					delta, deltatype, deltaedge = d, 3, m.bestedge[b]
+				}
+			}
+		}
+
+		// delta4: the least dual of a T-blossom
+		for b := m.n; b < 2*m.n; b++ {
+			if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 && m.label[b] == 2 && (deltatype == -1 || m.dualvar[b] < delta) {
+				delta, deltatype, deltablossom = m.dualvar[b], 4, b
+			}
+		}
+
+		if deltatype == -1 {
+			// the maximum cardinality optimum has been reached, but finish with a final
+			// update so the optimum is verifiable
+			deltatype = 1
+			delta = m.dualvar[0]
+			for v := 1; v < m.n; v++ {
+				if m.dualvar[v] < delta {
+					delta = m.dualvar[v]
+				}
+			}
+			if delta < 0 {
+				delta = 0
+			}
+		}
+
+		for v := 0; v < m.n; v++ {
+			switch m.label[m.inblossom[v]] {
+			case 1:
+				m.dualvar[v] -= delta
+			case 2:
+				m.dualvar[v] += delta
+			}
+		}
+		for b := m.n; b < 2*m.n; b++ {
+			if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 {
+				switch m.label[b] {
+				case 1:
+					m.dualvar[b] += delta
+				case 2:
+					m.dualvar[b] -= delta
+				}
+			}
+		}
+
+		switch deltatype {
+		case 1:
+			return false
+		case 2:
+			m.allowedge[deltaedge] = true
+			i, j := m.edges[deltaedge].i, m.edges[deltaedge].j
+			if m.label[m.inblossom[i]] == 0 {
+				i, j = j, i
+			}
+			m.queue = append(m.queue, i)
+		case 3:
+			m.allowedge[deltaedge] = true
+			m.queue = append(m.queue, m.edges[deltaedge].i)
+		case 4:
+			m.expandBlossom(deltablossom, false)
+		}
+	}
+}
+
+// scan labels every vertex reachable through an alternating path from the queued S-vertices,
+// reporting whether an augmenting path was found and used.
+func (m *blossomMatching) scan() bool {
+	for len(m.queue) > 0 {
+		v := m.queue[len(m.queue)-1]
+		m.queue = m.queue[:len(m.queue)-1]
+
+		for _, p := range m.neighbend[v] {
+			k := p / 2
+			w := m.endpoint[p]
+			if m.inblossom[v] == m.inblossom[w] {
+				// this edge is internal to a blossom
+				continue
+			}
+
+			var kslack int64
+			if !m.allowedge[k] {
+				kslack = m.slack(k)
+				if kslack <= 0 {
+					m.allowedge[k] = true
+				}
+			}
+
+			switch {
+			case m.allowedge[k] && m.label[m.inblossom[w]] == 0:
+				// w is free, so label it T and its mate S
+				m.assignLabel(w, 2, p^1)
+			case m.allowedge[k] && m.label[m.inblossom[w]] == 1:
+				// w is an S-vertex in another blossom, so there is a new blossom or an augmenting path
+				if base := m.scanBlossom(v, w); base >= 0 {
+					m.addBlossom(base, k)
+				} else {
+					m.augmentMatching(k)
+					return true
+				}
+			case m.allowedge[k] && m.label[w] == 0:
+				// w is inside a T-blossom but has not been reached from outside it yet
+				m.label[w] = 2
+				m.labelend[w] = p ^ 1
+			case !m.allowedge[k] && m.label[m.inblossom[w]] == 1:
+				// keep track of the least slack edge to another S-blossom
+				b := m.inblossom[v]
+				if m.bestedge[b] == -1 || kslack < m.slack(m.bestedge[b]) {
+					m.bestedge[b] = k
+				}
+			case !m.allowedge[k] && m.label[w] == 0:
+				// keep track of the least slack edge to a free vertex
+				if m.bestedge[w] == -1 || kslack < m.slack(m.bestedge[w]) {
+					m.bestedge[w] = k
+				}
+			}
+		}
+	}
+	return false
+}
+
+// at returns s[i], counting back from the end of s when i is negative.
+func at(s []int, i int) int {
+	if i < 0 {
+		i += len(s)
+	}
+	return s[i]
+}
+
+func indexOf(s []int, v int) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func reverseInts(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
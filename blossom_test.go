@@ -0,0 +1,88 @@
+package sock_pair_in_golang
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// bestMatching returns the largest number of edges any matching of the graph holds, and the
+// greatest weight of such a matching, by trying every matching.
+func bestMatching(nVertices int, edges []weightedEdge) (int, int64) {
+	used := make([]bool, nVertices)
+	var search func(k int) (int, int64)
+	search = func(k int) (int, int64) {
+		if k == len(edges) {
+			return 0, 0
+		}
+		bestSize, bestWeight := search(k + 1)
+		e := edges[k]
+		if !used[e.i] && !used[e.j] {
+			used[e.i], used[e.j] = true, true
+			size, weight := search(k + 1)
+			used[e.i], used[e.j] = false, false
+			if size+1 > bestSize || (size+1 == bestSize && weight+e.weight > bestWeight) {
+				bestSize, bestWeight = size+1, weight+e.weight
+			}
+		}
+		return bestSize, bestWeight
+	}
+	return search(0)
+}
+
+func Test_maxWeightMatching(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 500; n++ {
+		nVertices := r.Intn(9)
+		edges := make([]weightedEdge, 0)
+		for i := 0; i < nVertices; i++ {
+			for j := i + 1; j < nVertices; j++ {
+				if r.Intn(2) == 0 {
+					edges = append(edges, weightedEdge{i: i, j: j, weight: int64(r.Intn(10) + 1)})
+				}
+			}
+		}
+
+		mate, err := maxWeightMatching(context.Background(), nVertices, edges)
+		if err != nil {
+			t.Fatalf("maxWeightMatching() error = %v", err)
+		}
+
+		size, weight := 0, int64(0)
+		for v, w := range mate {
+			if w == -1 {
+				continue
+			}
+			if mate[w] != v {
+				t.Fatalf("maxWeightMatching(%d, %v) = %v, %d and %d disagree", nVertices, edges, mate, v, w)
+			}
+			if v < w {
+				size++
+				found := false
+				for _, e := range edges {
+					if (e.i == v && e.j == w) || (e.i == w && e.j == v) {
+						weight += e.weight
+						found = true
+					}
+				}
+				if !found {
+					t.Fatalf("maxWeightMatching(%d, %v) = %v, pairs %d and %d without an edge", nVertices, edges, mate, v, w)
+				}
+			}
+		}
+
+		wantSize, wantWeight := bestMatching(nVertices, edges)
+		if size != wantSize || weight != wantWeight {
+			t.Errorf("maxWeightMatching(%d, %v) = %v of size %d and weight %d, want size %d and weight %d",
+				nVertices, edges, mate, size, weight, wantSize, wantWeight)
+		}
+	}
+}
+
+func Test_maxWeightMatching_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := maxWeightMatching(ctx, 2, []weightedEdge{{i: 0, j: 1, weight: 1}}); err != ctx.Err() {
+		t.Errorf("maxWeightMatching() error = %v, want %v", err, ctx.Err())
+	}
+}
@@ -0,0 +1,252 @@
+package sock_pair_in_golang
+
+import (
+	"context"
+	"errors"
+)
+
+// DefaultSurfaceCapacity is the number of socks BoundedSurfacePairingStrategy lays out when no
+// Capacity is given, about as many as fit on a bed.
+const DefaultSurfaceCapacity = 20
+
+// SurfaceSlot describes an item laid out on a bounded surface, for an EvictionPolicy to choose
+// which item to put back. Times are counted in items drawn.
+type SurfaceSlot struct {
+	// Placed is when the item was laid out on the surface.
+	Placed int
+	// KeyLastUsed is when an item with the same match key, such as a Sock of the same color
+	// and pattern, was last drawn.
+	KeyLastUsed int
+}
+
+// EvictionPolicy decides what happens to a drawn item that pairs with nothing on a full surface.
+type EvictionPolicy interface {
+	// Evict is given the items on the surface, oldest first, and returns the index of the item
+	// to put back in the basket to make room for the drawn item, or -1 to set the drawn item
+	// aside instead. Items put back or set aside are drawn again on the next pass through the basket.
+	Evict(slots []SurfaceSlot) int
+}
+
+// SetAside is the EvictionPolicy of setting the drawn item aside in an overflow pile.
+type SetAside struct{}
+
+func (SetAside) Evict(slots []SurfaceSlot) int {
+	return -1
+}
+
+// ReturnOldest is the EvictionPolicy of putting the item that has been on the surface longest
+// back in the basket.
+type ReturnOldest struct{}
+
+func (ReturnOldest) Evict(slots []SurfaceSlot) int {
+	return 0
+}
+
+// ReturnLeastRecentlyUsed is the EvictionPolicy of putting back the oldest item of the match key,
+// such as the color, that has gone longest without being drawn.
+type ReturnLeastRecentlyUsed struct{}
+
+func (ReturnLeastRecentlyUsed) Evict(slots []SurfaceSlot) int {
+	victim := 0
+	for i, slot := range slots {
+		if slot.KeyLastUsed < slots[victim].KeyLastUsed {
+			victim = i
+		}
+	}
+	return victim
+}
+
+// boundedEntry is an item on a bounded surface, along with when and in which pass it was placed.
+type boundedEntry[T any, K comparable] struct {
+	item   T
+	key    K
+	placed int
+	pass   int
+}
+
+// boundedSurface is a surface that remembers the order items were placed in and when each match
+// key was last drawn, so an EvictionPolicy can choose an item to put back.
+type boundedSurface[T any, K comparable] struct {
+	rules    Rules[T, K]
+	stats    *PairingStats
+	entries  []boundedEntry[T, K]
+	lastUsed map[K]int
+	clock    int
+}
+
+func newBoundedSurface[T any, K comparable](rules Rules[T, K], stats *PairingStats) *boundedSurface[T, K] {
+	return &boundedSurface[T, K]{rules: rules, stats: stats, lastUsed: make(map[K]int)}
+}
+
+// take removes and returns an item from the surface that pairs with item, if there is one.
+func (s *boundedSurface[T, K]) take(item T) (T, bool) {
+	s.clock++
+	s.stats.Scans++
+	key := s.rules.Key(item)
+	s.lastUsed[key] = s.clock
+	for i, candidate := range s.entries {
+		if candidate.key != key {
+			continue
+		}
+
+		s.stats.Comparisons++
+		if s.rules.Complements(item, candidate.item) {
+			return s.remove(i), true
+		}
+	}
+
+	var none T
+	return none, false
+}
+
+// place lays item out on the surface during the given pass.
+func (s *boundedSurface[T, K]) place(item T, pass int) {
+	s.entries = append(s.entries, boundedEntry[T, K]{item: item, key: s.rules.Key(item), placed: s.clock, pass: pass})
+	s.stats.Placements++
+	if len(s.entries) > s.stats.SurfacePeak {
+		s.stats.SurfacePeak = len(s.entries)
+	}
+}
+
+// remove removes and returns the ith item on the surface.
+func (s *boundedSurface[T, K]) remove(i int) T {
+	item := s.entries[i].item
+	s.stats.Removals++
+	if res, err := removeSockFromBasket(s.entries, i); err == nil {
+		s.entries = res
+	}
+	return item
+}
+
+func (s *boundedSurface[T, K]) slots() []SurfaceSlot {
+	slots := make([]SurfaceSlot, len(s.entries))
+	for i, e := range s.entries {
+		slots[i] = SurfaceSlot{Placed: e.placed, KeyLastUsed: s.lastUsed[e.key]}
+	}
+	return slots
+}
+
+// clearPlacedBefore removes and returns every item placed before the given pass.
+func (s *boundedSurface[T, K]) clearPlacedBefore(pass int) []T {
+	removed := make([]T, 0)
+	kept := s.entries[:0]
+	for _, e := range s.entries {
+		if e.pass < pass {
+			removed = append(removed, e.item)
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	s.entries = kept
+	return removed
+}
+
+// clear removes and returns every item left on the surface.
+func (s *boundedSurface[T, K]) clear() []T {
+	remaining := make([]T, 0, len(s.entries))
+	for _, e := range s.entries {
+		remaining = append(remaining, e.item)
+	}
+	s.entries = nil
+	return remaining
+}
+
+// PairBoundedSurface pairs items as PairSurface does, but on a surface that holds at most
+// opts.Capacity items. When a drawn item pairs with nothing on a full surface, opts.Eviction
+// decides whether to set it aside or put an item from the surface back in the basket, and the
+// items set aside or put back are gone through again in another pass.
+//
+// An item that stays on the surface for a whole pass has been compared with every item left,
+// so is an orphan. A pass that neither pairs nor orphans an item is followed by one that sets
+// items aside, whatever opts.Eviction, so that pairing always finishes. It never modifies the
+// basket, and returns an error if opts has no Capacity.
+func PairBoundedSurface[T any, K comparable](ctx context.Context, items []T, rules Rules[T, K], opts PairingOptions) (Result[T], error) {
+	pairedItems := make([][]T, 0)
+	orphanedItems := make([]T, 0)
+	stats := PairingStats{}
+
+	if opts.Capacity < 1 {
+		return Result[T]{}, errors.New("bounded surface pairing requires a positive PairingOptions.Capacity")
+	}
+	eviction := opts.Eviction
+	if eviction == nil {
+		eviction = SetAside{}
+	}
+
+	surface := newBoundedSurface(rules, &stats)
+	pile := items
+	stalled := false
+	for len(pile) > 0 {
+		stats.Passes++
+		pairedBefore, orphanedBefore := len(pairedItems), len(orphanedItems)
+		policy := eviction
+		if stalled {
+			policy = SetAside{}
+		}
+
+		next := make([]T, 0)
+		for i, item := range pile {
+			if err := ctx.Err(); err != nil {
+				unprocessedItems := append(surface.clear(), pile[i:]...)
+				unprocessedItems = append(unprocessedItems, next...)
+				return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Unprocessed: unprocessedItems, Stats: stats}, err
+			}
+
+			stats.Steps++
+			stats.Draws++
+			// check if a matching item already exists on the surface
+			if matchingItem, ok := surface.take(item); ok {
+				pairedItems = append(pairedItems, rules.pair(item, matchingItem))
+				continue
+			}
+
+			if len(surface.entries) >= opts.Capacity {
+				victim := policy.Evict(surface.slots())
+				if victim < 0 || victim >= len(surface.entries) {
+					next = append(next, item)
+					continue
+				}
+				next = append(next, surface.remove(victim))
+			}
+			surface.place(item, stats.Passes)
+		}
+
+		if len(next) == 0 {
+			// every item left is on the surface, and has been compared with every other
+			orphanedItems = append(orphanedItems, surface.clear()...)
+		} else {
+			orphanedItems = append(orphanedItems, surface.clearPlacedBefore(stats.Passes)...)
+		}
+		stalled = len(pairedItems) == pairedBefore && len(orphanedItems) == orphanedBefore
+		pile = next
+	}
+
+	return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Stats: stats}, nil
+}
+
+// BoundedSurfacePairingStrategy is the process of laying socks out on a surface, as
+// SurfacePairingStrategy does, when the surface only has room for so many socks. It never
+// modifies the basket.
+type BoundedSurfacePairingStrategy struct {
+	// Matcher decides which socks pair. A nil Matcher uses ExactMatcher.
+	Matcher Matcher
+	// Capacity is the number of socks the surface holds. Zero uses DefaultSurfaceCapacity.
+	Capacity int
+	// Eviction decides what to do with a Sock that pairs with nothing on a full surface.
+	// A nil Eviction sets the Sock aside.
+	Eviction EvictionPolicy
+}
+
+func (s BoundedSurfacePairingStrategy) PairSocks(freshSocks Socks) PairingResult {
+	res, _ := s.PairSocksContext(context.Background(), freshSocks)
+	return res
+}
+
+func (s BoundedSurfacePairingStrategy) PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error) {
+	capacity := s.Capacity
+	if capacity == 0 {
+		capacity = DefaultSurfaceCapacity
+	}
+	res, err := PairBoundedSurface(ctx, freshSocks, MatcherRules(s.Matcher), PairingOptions{Capacity: capacity, Eviction: s.Eviction})
+	return newPairingResult(res), err
+}
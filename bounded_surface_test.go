@@ -0,0 +1,136 @@
+package sock_pair_in_golang_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/sockpairtest"
+)
+
+// returnNewest is an EvictionPolicy of putting back the Sock most recently laid out.
+type returnNewest struct{}
+
+func (returnNewest) Evict(slots []sockpair.SurfaceSlot) int {
+	return len(slots) - 1
+}
+
+var evictionPolicies = []sockpair.EvictionPolicy{
+	nil,
+	sockpair.SetAside{},
+	sockpair.ReturnOldest{},
+	sockpair.ReturnLeastRecentlyUsed{},
+	returnNewest{},
+}
+
+func TestBoundedSurfacePairingStrategy_PairSocks(t *testing.T) {
+	for _, eviction := range evictionPolicies {
+		for _, capacity := range []int{0, 1, 2} {
+			t.Run(fmt.Sprintf("%T/%d", eviction, capacity), func(t *testing.T) {
+				sockpairtest.TestStrategy(t, sockpair.BoundedSurfacePairingStrategy{Capacity: capacity, Eviction: eviction})
+			})
+		}
+	}
+}
+
+func TestBoundedSurfacePairingStrategy_PairSocks_accountsForEverySock(t *testing.T) {
+	r := newRand(t)
+	for n := 0; n < 100; n++ {
+		g := sockpair.BasketGenerator{Variety: rainbow, Pairs: r.Intn(40), LossProbability: 0.2, Zipf: 1, Rand: r}
+		basket, _ := g.Generate()
+		sockpair.AssignIDs(basket, "sock")
+		want := sockpair.SurfacePairingStrategy{}.PairSocks(basket)
+
+		for _, eviction := range evictionPolicies {
+			strategy := sockpair.BoundedSurfacePairingStrategy{Capacity: 1 + r.Intn(10), Eviction: eviction}
+			got := strategy.PairSocks(basket)
+			if err := sockpair.Audit(basket, got); err != nil {
+				t.Fatalf("Audit(%+v.PairSocks()) error = %v", strategy, err)
+			}
+			// with only left and right socks, every strategy leaves the same number of orphans
+			if len(got.Orphans) != len(want.Orphans) {
+				t.Errorf("%+v.PairSocks() orphans = %d, want %d", strategy, len(got.Orphans), len(want.Orphans))
+			}
+			if got.Stats.SurfacePeak > strategy.Capacity {
+				t.Errorf("%+v.PairSocks() laid out %d socks", strategy, got.Stats.SurfacePeak)
+			}
+		}
+	}
+}
+
+func TestBoundedSurfacePairingStrategy_PairSocks_passes(t *testing.T) {
+	basket := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		2,
+		false,
+	), newRand(t))
+
+	// a surface big enough for every sock needs a single pass, like SurfacePairingStrategy
+	roomy := sockpair.BoundedSurfacePairingStrategy{Capacity: len(basket)}.PairSocks(basket)
+	if roomy.Stats.Passes != 1 {
+		t.Errorf("BoundedSurfacePairingStrategy.PairSocks() passes = %d, want 1", roomy.Stats.Passes)
+	}
+	if want := (sockpair.SurfacePairingStrategy{}).PairSocks(basket).Stats.SurfacePeak; roomy.Stats.SurfacePeak != want {
+		t.Errorf("BoundedSurfacePairingStrategy.PairSocks() surface peak = %d, want %d", roomy.Stats.SurfacePeak, want)
+	}
+
+	cramped := sockpair.BoundedSurfacePairingStrategy{Capacity: 5}.PairSocks(basket)
+	if cramped.Stats.Passes <= roomy.Stats.Passes || cramped.Stats.Draws <= roomy.Stats.Draws {
+		t.Errorf("BoundedSurfacePairingStrategy.PairSocks() with room for 5 = %+v, want more passes and draws than %+v", cramped.Stats, roomy.Stats)
+	}
+}
+
+func TestBoundedSurfacePairingStrategy_PairSocks_stalled(t *testing.T) {
+	// putting back the oldest Sock from a surface of two sends each Sock back just before its partner is drawn
+	basket := sockpair.Socks{
+		{Color: "red", Side: sockpair.Left},
+		{Color: "blue", Side: sockpair.Left},
+		{Color: "green", Side: sockpair.Left},
+		{Color: "red", Side: sockpair.Right},
+		{Color: "blue", Side: sockpair.Right},
+		{Color: "green", Side: sockpair.Right},
+	}
+	got := sockpair.BoundedSurfacePairingStrategy{Capacity: 2, Eviction: sockpair.ReturnOldest{}}.PairSocks(basket)
+	if len(got.Pairs) != 3 || len(got.Orphans) != 0 {
+		t.Errorf("BoundedSurfacePairingStrategy.PairSocks() = %v, %v, want 3 pairs", got.Pairs, got.Orphans)
+	}
+}
+
+func TestReturnLeastRecentlyUsed_Evict(t *testing.T) {
+	slots := []sockpair.SurfaceSlot{{Placed: 1, KeyLastUsed: 9}, {Placed: 2, KeyLastUsed: 4}, {Placed: 3, KeyLastUsed: 4}}
+	if got := (sockpair.ReturnLeastRecentlyUsed{}).Evict(slots); got != 1 {
+		t.Errorf("ReturnLeastRecentlyUsed.Evict() = %d, want 1", got)
+	}
+}
+
+func TestPairBoundedSurface_withoutCapacity(t *testing.T) {
+	_, err := sockpair.PairBoundedSurface(context.Background(), sockpair.Socks{{Color: "red"}}, sockpair.SockRules(), sockpair.PairingOptions{})
+	if err == nil {
+		t.Errorf("PairBoundedSurface() without Capacity returned no error")
+	}
+}
+
+func BenchmarkBoundedSurfacePairingStrategy_PairSocks_capacity(b *testing.B) {
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		10,
+		false,
+	), newRand(b))
+	for _, eviction := range []sockpair.EvictionPolicy{sockpair.SetAside{}, sockpair.ReturnOldest{}, sockpair.ReturnLeastRecentlyUsed{}} {
+		for _, capacity := range []int{5, 10, 20, 40, 80} {
+			strategy := sockpair.BoundedSurfacePairingStrategy{Capacity: capacity, Eviction: eviction}
+			b.Run(fmt.Sprintf("%T/%d", eviction, capacity), func(b *testing.B) {
+				var stats sockpair.PairingStats
+				for i := 0; i < b.N; i++ {
+					stats = strategy.PairSocks(testSocks).Stats
+				}
+				b.ReportMetric(float64(stats.Passes), "passes/op")
+				b.ReportMetric(float64(stats.Comparisons), "comparisons/op")
+				b.ReportMetric(float64(stats.Draws), "draws/op")
+			})
+		}
+	}
+}
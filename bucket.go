@@ -0,0 +1,61 @@
+package sock_pair_in_golang
+
+import "context"
+
+// BucketPairingStrategy is the process of first throwing every Sock into a pile by color, then
+// pairing the socks within each pile. It never modifies the basket.
+type BucketPairingStrategy struct {
+	// By is the attributes socks are piled up by. Socks in different piles never pair, so By
+	// should only hold attributes that Inner compares. Zero piles socks by ColorAttribute.
+	By SockAttribute
+	// Inner pairs the socks within each pile. A nil Inner uses SequentialPairingStrategy.
+	Inner SockPairingStrategy
+}
+
+func (s BucketPairingStrategy) PairSocks(freshSocks Socks) PairingResult {
+	res, _ := s.PairSocksContext(context.Background(), freshSocks)
+	return res
+}
+
+func (s BucketPairingStrategy) PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error) {
+	by := s.By
+	if by == 0 {
+		by = ColorAttribute
+	}
+	inner := s.Inner
+	if inner == nil {
+		inner = SequentialPairingStrategy{}
+	}
+
+	// every sock is drawn from the basket and thrown onto its pile
+	res := PairingResult{Pairs: make(SockPairs, 0), Orphans: make(Socks, 0)}
+	res.Stats.Draws = len(freshSocks)
+	res.Stats.Placements = len(freshSocks)
+	res.Stats.Steps = len(freshSocks)
+	keys := make([]Sock, 0)
+	buckets := make(map[Sock]Socks)
+	for _, sock := range freshSocks {
+		key := AttributeMatcher{Attributes: by}.MatchKey(sock)
+		if _, ok := buckets[key]; !ok {
+			keys = append(keys, key)
+		}
+		buckets[key] = append(buckets[key], sock)
+	}
+	res.Stats.SurfacePeak = len(freshSocks)
+
+	for i, key := range keys {
+		bucketRes, err := PairContext(ctx, inner, buckets[key])
+		res.Pairs = append(res.Pairs, bucketRes.Pairs...)
+		res.Orphans = append(res.Orphans, bucketRes.Orphans...)
+		res.Stats = res.Stats.add(bucketRes.Stats)
+		if err != nil {
+			res.Unprocessed = append(Socks{}, bucketRes.Unprocessed...)
+			for _, key := range keys[i+1:] {
+				res.Unprocessed = append(res.Unprocessed, buckets[key]...)
+			}
+			return res, err
+		}
+	}
+
+	return res, nil
+}
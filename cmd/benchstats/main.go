@@ -0,0 +1,136 @@
+// Command benchstats reads the output of go test -bench -benchmem, or benchmark_results.csv,
+// and summarizes it.
+//
+// Usage:
+//
+//	benchstats convert [-json] [file]
+//	benchstats summary [-unit ns/op] [file]
+//	benchstats compare [-alpha 0.05] old new
+//
+// convert writes the results as CSV, or JSON with -json, with a typed column for each metric.
+// summary writes the mean, median, standard deviation and 95% confidence interval of each
+// metric of each benchmark, followed by a table comparing the noOrphans, singleOrphan and
+// allOrphans variants of each strategy. compare compares two sets of results, as benchstat does.
+//
+// Results are read from the named file, or from standard input if there is none or it is "-".
+//
+//	go test -bench=. -count 10 -benchmem | benchstats summary
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit statuses.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+const usage = `usage:
+	benchstats convert [-json] [file]
+	benchstats summary [-unit ns/op] [file]
+	benchstats compare [-alpha 0.05] old new`
+
+// run runs the command with the given arguments, returning its exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+		return exitUsage
+	}
+
+	flags := flag.NewFlagSet("benchstats "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	var err error
+	switch args[0] {
+	case "convert":
+		asJSON := flags.Bool("json", false, "write JSON rather than CSV")
+		if flags.Parse(args[1:]) != nil || flags.NArg() > 1 {
+			return exitUsage
+		}
+		var results []Result
+		if results, err = readResults(flags.Arg(0), stdin); err == nil {
+			if *asJSON {
+				err = WriteJSON(stdout, results)
+			} else {
+				err = WriteCSV(stdout, results)
+			}
+		}
+	case "summary":
+		unit := flags.String("unit", "ns/op", "metric to compare the variants of each strategy by")
+		if flags.Parse(args[1:]) != nil || flags.NArg() > 1 {
+			return exitUsage
+		}
+		var results []Result
+		if results, err = readResults(flags.Arg(0), stdin); err == nil {
+			if err = WriteSummary(stdout, results); err == nil {
+				fmt.Fprintln(stdout)
+				err = WriteVariants(stdout, results, *unit)
+			}
+		}
+	case "compare":
+		alpha := flags.Float64("alpha", 0.05, "largest p-value at which a change is reported")
+		if flags.Parse(args[1:]) != nil || flags.NArg() != 2 {
+			flags.Usage()
+			return exitUsage
+		}
+		var before, after []Result
+		if before, err = readResults(flags.Arg(0), stdin); err == nil {
+			if after, err = readResults(flags.Arg(1), stdin); err == nil {
+				if len(sharedBenchmarks(before, after)) == 0 {
+					fmt.Fprintf(stderr, "benchstats: warning: %s and %s share no benchmarks\n",
+						displayName(flags.Arg(0)), displayName(flags.Arg(1)))
+				}
+				err = WriteComparison(stdout, before, after, *alpha)
+			}
+		}
+	default:
+		fmt.Fprintf(stderr, "benchstats: unknown command %q\n%s\n", args[0], usage)
+		return exitUsage
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "benchstats: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// readResults reads the results from the named file, or from stdin if name is empty or "-".
+func readResults(name string, stdin io.Reader) ([]Result, error) {
+	r := stdin
+	if name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	results, err := Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", displayName(name), err)
+	}
+	return results, nil
+}
+
+func displayName(name string) string {
+	if name == "" || name == "-" {
+		return "standard input"
+	}
+	return name
+}
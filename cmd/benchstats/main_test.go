@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// faster is testOutput with the noOrphans benchmark taking half as long.
+const faster = `BenchmarkSurfacePairingStrategy_PairSocks_noOrphans-8   	    6219	     95000 ns/op	   91386 B/op	     628 allocs/op
+BenchmarkSurfacePairingStrategy_PairSocks_noOrphans-8   	    6000	     96000 ns/op	   91390 B/op	     629 allocs/op
+`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	before, after := filepath.Join(dir, "old.txt"), filepath.Join(dir, "new.txt")
+	if err := os.WriteFile(before, []byte(testOutput), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(after, []byte(faster), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  []string
+	}{
+		{name: "convert", args: []string{"convert"}, stdin: testOutput, want: []string{"name,procs,iterations,ns/op,B/op,allocs/op,passes/op\n"}},
+		{name: "convert json", args: []string{"convert", "-json", before}, want: []string{`"ns/op": 190377`}},
+		{
+			name:  "summary",
+			args:  []string{"summary", "-"},
+			stdin: testOutput,
+			// the 95% CI of two values is their mean ± 12.706 times their standard error
+			want: []string{"ns/op      2  1.957e+05  1.957e+05  7512    [1.282e+05, 2.632e+05]", "noOrphans", "1.957e+05 ±34%  -"},
+		},
+		{
+			name: "compare",
+			args: []string{"compare", "-alpha", "1", before, after},
+			want: []string{"1.957e+05 ±34%", "9.55e+04 ±7%", "-51.20% (p=0.245 n=2+2)", "+0.00% (p=1.000 n=2+2)"},
+		},
+		{
+			name: "compare noise",
+			args: []string{"compare", before, after},
+			want: []string{"~ (p=0.245 n=2+2)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr); status != exitOK {
+				t.Fatalf("run() = %d, want %d; stderr: %s", status, exitOK, stderr.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("run() printed %s, want it to contain %q", stdout.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestRun_compareLegacy(t *testing.T) {
+	after := filepath.Join(t.TempDir(), "new.txt")
+	if err := os.WriteFile(after, []byte(faster), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// benchmark_results.csv names the benchmark SurfacePairingStrategy_pairSocks_noOrphans
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"compare", "../../benchmark_results.csv", after}, nil, &stdout, &stderr); status != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", status, exitOK, stderr.String())
+	}
+	if want := "SurfacePairingStrategy_PairSocks_noOrphans  ns/op"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("run() printed %s, want it to contain %q", stdout.String(), want)
+	}
+	if stderr.Len() > 0 {
+		t.Errorf("run() printed %q to stderr, want nothing", stderr.String())
+	}
+}
+
+func TestRun_compareNothingShared(t *testing.T) {
+	dir := t.TempDir()
+	before, after := filepath.Join(dir, "old.txt"), filepath.Join(dir, "new.txt")
+	if err := os.WriteFile(before, []byte(strings.ReplaceAll(faster, "Surface", "Sequential")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(after, []byte(faster), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"compare", before, after}, nil, &stdout, &stderr); status != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", status, exitOK, stderr.String())
+	}
+	if want := "share no benchmarks"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("run() printed %q to stderr, want it to contain %q", stderr.String(), want)
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "no command", want: exitUsage},
+		{name: "unknown command", args: []string{"plot"}, want: exitUsage},
+		{name: "compare one file", args: []string{"compare", "old.txt"}, want: exitUsage},
+		{name: "missing file", args: []string{"summary", filepath.Join(t.TempDir(), "missing.txt")}, want: exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tt.args, strings.NewReader(""), &stdout, &stderr); status != tt.want {
+				t.Errorf("run() = %d, want %d", status, tt.want)
+			}
+			if stderr.Len() == 0 {
+				t.Errorf("run() printed no error")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Result is one run of a benchmark, as reported on a line of go test -bench output.
+type Result struct {
+	// Name is the name of the benchmark without its Benchmark prefix or GOMAXPROCS suffix,
+	// such as "SurfacePairingStrategy_PairSocks_noOrphans".
+	Name string `json:"name"`
+	// Procs is the GOMAXPROCS the benchmark ran with, or 0 if not reported.
+	Procs int `json:"procs"`
+	// Iterations is the number of times the benchmark loop ran.
+	Iterations int `json:"iterations"`
+	// Metrics holds the value of each metric reported by its unit, such as "ns/op", "B/op"
+	// and "allocs/op".
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// standardUnits are the units reported by go test -bench -benchmem, in the order they are reported.
+var standardUnits = []string{"ns/op", "B/op", "allocs/op"}
+
+// Parse reads the results from go test -bench output, ignoring lines that are not results.
+// It also reads benchmark_results.csv, which holds the same lines split into columns with the
+// units left in the values.
+func Parse(r io.Reader) ([]Result, error) {
+	results := make([]Result, 0)
+	lines := bufio.NewScanner(r)
+	for n := 1; lines.Scan(); n++ {
+		line := lines.Text()
+		if !strings.HasPrefix(line, "Benchmark") {
+			continue
+		}
+
+		var fields []string
+		// go test separates the fields of a result with tabs
+		if strings.Contains(line, ",") && !strings.Contains(line, "\t") {
+			var err error
+			if fields, err = legacyFields(line); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+		} else {
+			fields = strings.Fields(line)
+		}
+		// a benchmark's name is printed alone before any output it logs
+		if len(fields) < 2 {
+			continue
+		}
+
+		res, err := parseResult(fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		results = append(results, res)
+	}
+	return results, lines.Err()
+}
+
+// legacyFields splits a line of benchmark_results.csv into the fields of a go test -bench line.
+func legacyFields(line string) ([]string, error) {
+	record, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, 2*len(record))
+	for _, cell := range record {
+		fields = append(fields, strings.Fields(cell)...)
+	}
+	return fields, nil
+}
+
+// parseResult parses the fields of a go test -bench line: the name, the iterations and then
+// pairs of values and units.
+func parseResult(fields []string) (Result, error) {
+	res := Result{Metrics: make(map[string]float64)}
+	res.Name, res.Procs = splitName(fields[0])
+
+	iterations, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid iterations %q for %s", fields[1], fields[0])
+	}
+	res.Iterations = iterations
+
+	metrics := fields[2:]
+	if len(metrics)%2 != 0 {
+		return Result{}, fmt.Errorf("metric %q of %s has no unit", metrics[len(metrics)-1], fields[0])
+	}
+	for i := 0; i < len(metrics); i += 2 {
+		value, err := strconv.ParseFloat(metrics[i], 64)
+		if err != nil {
+			return Result{}, fmt.Errorf("invalid %s %q for %s", metrics[i+1], metrics[i], fields[0])
+		}
+		res.Metrics[metrics[i+1]] = value
+	}
+	return res, nil
+}
+
+// splitName splits a benchmark name such as "BenchmarkSurface-12" into "Surface" and 12.
+func splitName(name string) (string, int) {
+	name = strings.TrimPrefix(name, "Benchmark")
+	if i := strings.LastIndex(name, "-"); i >= 0 {
+		if procs, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i], procs
+		}
+	}
+	return name, 0
+}
+
+// units returns the units reported by any of the results, standard units first.
+func units(results []Result) []string {
+	seen := make(map[string]bool)
+	others := make([]string, 0)
+	for _, res := range results {
+		for unit := range res.Metrics {
+			if !seen[unit] {
+				seen[unit] = true
+				if !isStandardUnit(unit) {
+					others = append(others, unit)
+				}
+			}
+		}
+	}
+	sort.Strings(others)
+
+	all := make([]string, 0, len(seen))
+	for _, unit := range standardUnits {
+		if seen[unit] {
+			all = append(all, unit)
+		}
+	}
+	return append(all, others...)
+}
+
+func isStandardUnit(unit string) bool {
+	for _, u := range standardUnits {
+		if u == unit {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteCSV writes the results as CSV with a column for each unit, leaving a cell empty where a
+// result did not report that unit.
+func WriteCSV(w io.Writer, results []Result) error {
+	columns := units(results)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"name", "procs", "iterations"}, columns...)); err != nil {
+		return err
+	}
+	for _, res := range results {
+		record := []string{res.Name, strconv.Itoa(res.Procs), strconv.Itoa(res.Iterations)}
+		for _, unit := range columns {
+			cell := ""
+			if value, ok := res.Metrics[unit]; ok {
+				cell = strconv.FormatFloat(value, 'f', -1, 64)
+			}
+			record = append(record, cell)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the results as a JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
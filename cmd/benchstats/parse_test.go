@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testOutput = `goos: linux
+goarch: amd64
+pkg: github.com/burtawicz/sock-pair-in-golang
+BenchmarkSurfacePairingStrategy_PairSocks_noOrphans-8   	    6219	    190377 ns/op	   91386 B/op	     628 allocs/op
+BenchmarkSurfacePairingStrategy_PairSocks_noOrphans
+    sock_pairing_test.go:25: seed: 1
+BenchmarkSurfacePairingStrategy_PairSocks_noOrphans-8   	    6000	    201000 ns/op	   91390 B/op	     629 allocs/op
+BenchmarkBoundedSurfacePairingStrategy_PairSocks_capacity/sock_pair_in_golang.SetAside/5-8         	    1234	    950000 ns/op	         7.000 passes/op
+PASS
+ok  	github.com/burtawicz/sock-pair-in-golang	12.345s
+`
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.NewReader(testOutput))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []Result{
+		{Name: "SurfacePairingStrategy_PairSocks_noOrphans", Procs: 8, Iterations: 6219, Metrics: map[string]float64{"ns/op": 190377, "B/op": 91386, "allocs/op": 628}},
+		{Name: "SurfacePairingStrategy_PairSocks_noOrphans", Procs: 8, Iterations: 6000, Metrics: map[string]float64{"ns/op": 201000, "B/op": 91390, "allocs/op": 629}},
+		{Name: "BoundedSurfacePairingStrategy_PairSocks_capacity/sock_pair_in_golang.SetAside/5", Procs: 8, Iterations: 1234, Metrics: map[string]float64{"ns/op": 950000, "passes/op": 7}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParse_legacy(t *testing.T) {
+	f, err := os.Open("../../benchmark_results.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err := Parse(f)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(got) != 120 {
+		t.Fatalf("Parse() read %d results, want 120", len(got))
+	}
+	want := Result{Name: "RandomPairingStrategy_pairSocks_noOrphans", Procs: 12, Iterations: 100, Metrics: map[string]float64{"ns/op": 2708326913, "B/op": 92930, "allocs/op": 14}}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Errorf("Parse() = %+v, want %+v", got[0], want)
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	for _, output := range []string{
+		"BenchmarkSurface-8\tmany\t190377 ns/op\n",
+		"BenchmarkSurface-8\t100\t190377\n",
+		"BenchmarkSurface-8\t100\tfast ns/op\n",
+		"BenchmarkSurface-8,100,\"190377 ns/op\n",
+	} {
+		if _, err := Parse(strings.NewReader(output)); err == nil {
+			t.Errorf("Parse(%q) returned no error", output)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	results, err := Parse(strings.NewReader(testOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := WriteCSV(&b, results); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := `name,procs,iterations,ns/op,B/op,allocs/op,passes/op
+SurfacePairingStrategy_PairSocks_noOrphans,8,6219,190377,91386,628,
+SurfacePairingStrategy_PairSocks_noOrphans,8,6000,201000,91390,629,
+BoundedSurfacePairingStrategy_PairSocks_capacity/sock_pair_in_golang.SetAside/5,8,1234,950000,,,7
+`
+	if b.String() != want {
+		t.Errorf("WriteCSV() = %s, want %s", b.String(), want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	results, err := Parse(strings.NewReader(testOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := WriteJSON(&b, results); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var got []Result
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, results) {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", got, results)
+	}
+}
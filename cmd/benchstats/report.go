@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// variants are the baskets each pairing strategy is benchmarked with, as the suffixes of the
+// benchmark names, in the order they are reported.
+var variants = []string{"noOrphans", "singleOrphan", "allOrphans"}
+
+// splitVariant splits a benchmark name such as "Surface_PairSocks_noOrphans" into the
+// benchmark it is a variant of and the variant, or returns an empty variant if it is not one.
+func splitVariant(name string) (string, string) {
+	i := strings.LastIndex(name, "_")
+	if i < 0 {
+		return name, ""
+	}
+	for _, variant := range variants {
+		if strings.EqualFold(name[i+1:], variant) {
+			return name[:i], variant
+		}
+	}
+	return name, ""
+}
+
+func variantIndex(variant string) int {
+	for i, v := range variants {
+		if v == variant {
+			return i
+		}
+	}
+	return len(variants)
+}
+
+// samples holds the values of each metric of each benchmark.
+type samples map[string]map[string][]float64
+
+func group(results []Result) samples {
+	s := make(samples)
+	for _, res := range results {
+		if s[res.Name] == nil {
+			s[res.Name] = make(map[string][]float64)
+		}
+		for unit, value := range res.Metrics {
+			s[res.Name][unit] = append(s[res.Name][unit], value)
+		}
+	}
+	return s
+}
+
+// names returns the benchmarks in s, ordered by the benchmark they are a variant of and then
+// by variant.
+func (s samples) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		baseI, variantI := splitVariant(names[i])
+		baseJ, variantJ := splitVariant(names[j])
+		if baseI != baseJ {
+			return baseI < baseJ
+		}
+		if a, b := variantIndex(variantI), variantIndex(variantJ); a != b {
+			return a < b
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+// formatSummary formats the mean of s along with the relative size of its confidence interval.
+func formatSummary(s Summary) string {
+	return fmt.Sprintf("%s ±%.0f%%", formatValue(s.Mean), 100*s.RelativeCI())
+}
+
+// WriteSummary writes the summary of each metric of each benchmark in results.
+func WriteSummary(w io.Writer, results []Result) error {
+	s := group(results)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "name\tunit\tn\tmean\tmedian\tstddev\t95% CI")
+	for _, name := range s.names() {
+		for _, unit := range units(results) {
+			values, ok := s[name][unit]
+			if !ok {
+				continue
+			}
+			sum := summarize(values)
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\t[%s, %s]\n", name, unit, sum.N,
+				formatValue(sum.Mean), formatValue(sum.Median), formatValue(sum.StdDev),
+				formatValue(sum.CILow), formatValue(sum.CIHigh))
+		}
+	}
+	return tw.Flush()
+}
+
+// WriteVariants writes the mean of the given metric for each benchmark that has variants, with
+// a column for each variant, so strategies can be compared side by side.
+func WriteVariants(w io.Writer, results []Result, unit string) error {
+	s := group(results)
+	bases := make([]string, 0)
+	cells := make(map[string]map[string]string)
+	for _, name := range s.names() {
+		base, variant := splitVariant(name)
+		values, ok := s[name][unit]
+		if variant == "" || !ok {
+			continue
+		}
+		if cells[base] == nil {
+			bases = append(bases, base)
+			cells[base] = make(map[string]string)
+		}
+		cells[base][variant] = formatSummary(summarize(values))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\t%s\n", unit, strings.Join(variants, "\t"))
+	for _, base := range bases {
+		row := make([]string, len(variants))
+		for i, variant := range variants {
+			row[i] = cells[base][variant]
+			if row[i] == "" {
+				row[i] = "-"
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", base, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// sharedBenchmarks returns the benchmarks in both before and after, ordered as samples.names
+// orders after, each as a pair of its name in before and in after. Names are matched ignoring
+// case, as benchmark_results.csv names its benchmarks pairSocks where they are now PairSocks.
+func sharedBenchmarks(before, after []Result) [][2]string {
+	oldNames := make(map[string]string)
+	for name := range group(before) {
+		oldNames[strings.ToLower(name)] = name
+	}
+	shared := make([][2]string, 0)
+	for _, name := range group(after).names() {
+		if oldName, ok := oldNames[strings.ToLower(name)]; ok {
+			shared = append(shared, [2]string{oldName, name})
+		}
+	}
+	return shared
+}
+
+// WriteComparison writes a benchstat style comparison of each metric of the benchmarks in both
+// before and after, matching their names ignoring case. A change whose p-value is above alpha is
+// shown as ~, as it may be noise.
+func WriteComparison(w io.Writer, before, after []Result, alpha float64) error {
+	oldSamples, newSamples := group(before), group(after)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "name\tunit\told\tnew\tdelta")
+	for _, names := range sharedBenchmarks(before, after) {
+		oldName, name := names[0], names[1]
+		for _, unit := range units(append(append([]Result{}, before...), after...)) {
+			oldValues, okOld := oldSamples[oldName][unit]
+			newValues, okNew := newSamples[name][unit]
+			if !okOld || !okNew {
+				continue
+			}
+
+			oldSum, newSum := summarize(oldValues), summarize(newValues)
+			p := mannWhitneyU(oldValues, newValues)
+			delta := "~"
+			if p <= alpha && oldSum.Mean != 0 {
+				delta = fmt.Sprintf("%+.2f%%", 100*(newSum.Mean-oldSum.Mean)/oldSum.Mean)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s (p=%.3f n=%d+%d)\n", name, unit,
+				formatSummary(oldSum), formatSummary(newSum), delta, p, oldSum.N, newSum.N)
+		}
+	}
+	return tw.Flush()
+}
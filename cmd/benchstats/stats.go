@@ -0,0 +1,131 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Summary describes a sample of the values of one metric of a benchmark.
+type Summary struct {
+	N      int
+	Mean   float64
+	Median float64
+	// StdDev is the sample standard deviation, or zero for a single value.
+	StdDev float64
+	// CILow and CIHigh bound the 95% confidence interval of the mean, using Student's t
+	// distribution. They equal Mean for a single value.
+	CILow, CIHigh float64
+}
+
+// summarize returns the summary of values, which must not be empty.
+func summarize(values []float64) Summary {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+
+	s := Summary{N: n}
+	for _, v := range sorted {
+		s.Mean += v
+	}
+	s.Mean /= float64(n)
+	if n%2 == 1 {
+		s.Median = sorted[n/2]
+	} else {
+		s.Median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	s.CILow, s.CIHigh = s.Mean, s.Mean
+	if n > 1 {
+		sumSquares := 0.0
+		for _, v := range sorted {
+			sumSquares += (v - s.Mean) * (v - s.Mean)
+		}
+		s.StdDev = math.Sqrt(sumSquares / float64(n-1))
+		margin := tQuantile975(n-1) * s.StdDev / math.Sqrt(float64(n))
+		s.CILow, s.CIHigh = s.Mean-margin, s.Mean+margin
+	}
+	return s
+}
+
+// RelativeCI returns half the width of the confidence interval as a fraction of the mean.
+func (s Summary) RelativeCI() float64 {
+	if s.Mean == 0 {
+		return 0
+	}
+	return (s.CIHigh - s.CILow) / 2 / math.Abs(s.Mean)
+}
+
+// t975 holds the 97.5th percentiles of Student's t distribution with 1 to 30 degrees of freedom.
+var t975 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tQuantile975 returns the 97.5th percentile of Student's t distribution with df degrees of
+// freedom, which bounds a two sided 95% confidence interval.
+func tQuantile975(df int) float64 {
+	switch {
+	case df < 1:
+		return math.NaN()
+	case df <= len(t975):
+		return t975[df-1]
+	case df <= 40:
+		return 2.021
+	case df <= 60:
+		return 2.000
+	case df <= 120:
+		return 1.980
+	default:
+		return 1.960
+	}
+}
+
+// mannWhitneyU returns the two sided p-value of the Mann-Whitney U test that the samples a and
+// b come from the same distribution, as benchstat uses. It uses the normal approximation with a
+// correction for ties, which is close enough for the ten or so runs of a benchmark usually taken.
+func mannWhitneyU(a, b []float64) float64 {
+	type value struct {
+		v     float64
+		fromA bool
+	}
+	values := make([]value, 0, len(a)+len(b))
+	for _, v := range a {
+		values = append(values, value{v, true})
+	}
+	for _, v := range b {
+		values = append(values, value{v, false})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].v < values[j].v
+	})
+
+	// rank the values from 1, giving tied values the average of their ranks
+	rankSumA, ties := 0.0, 0.0
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].v == values[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if values[k].fromA {
+				rankSumA += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	n1, n2 := float64(len(a)), float64(len(b))
+	n := n1 + n2
+	u := rankSumA - n1*(n1+1)/2
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if n1 == 0 || n2 == 0 || variance <= 0 {
+		return 1
+	}
+	z := math.Max(math.Abs(u-mean)-0.5, 0) / math.Sqrt(variance)
+	return math.Erfc(z / math.Sqrt2)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	got := summarize([]float64{4, 1, 3, 2})
+	// the standard deviation of 1..4 is sqrt(5/3), and t(0.975, 3) = 3.182
+	margin := 3.182 * math.Sqrt(5.0/3) / 2
+	want := Summary{N: 4, Mean: 2.5, Median: 2.5, StdDev: math.Sqrt(5.0 / 3), CILow: 2.5 - margin, CIHigh: 2.5 + margin}
+	if got != want {
+		t.Errorf("summarize() = %+v, want %+v", got, want)
+	}
+
+	if got, want := summarize([]float64{7}), (Summary{N: 1, Mean: 7, Median: 7, CILow: 7, CIHigh: 7}); got != want {
+		t.Errorf("summarize() = %+v, want %+v", got, want)
+	}
+	if got := summarize([]float64{3, 1, 2}).Median; got != 2 {
+		t.Errorf("summarize() median = %v, want 2", got)
+	}
+}
+
+func TestTQuantile975(t *testing.T) {
+	for df, want := range map[int]float64{1: 12.706, 9: 2.262, 30: 2.042, 35: 2.021, 1000: 1.960} {
+		if got := tQuantile975(df); got != want {
+			t.Errorf("tQuantile975(%d) = %v, want %v", df, got, want)
+		}
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	low := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	high := []float64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	mixed := []float64{1.5, 2.5, 3.5, 4.5, 5.5, 6.5, 7.5, 8.5, 9.5, 10.5}
+
+	// U = 0, so z = (50 - 0.5) / sqrt(175)
+	if got, want := mannWhitneyU(low, high), math.Erfc(49.5/math.Sqrt(175)/math.Sqrt2); math.Abs(got-want) > 1e-12 {
+		t.Errorf("mannWhitneyU() of separate samples = %v, want %v", got, want)
+	}
+	if got := mannWhitneyU(low, mixed); got < 0.5 {
+		t.Errorf("mannWhitneyU() of interleaved samples = %v, want a large p-value", got)
+	}
+	if got := mannWhitneyU([]float64{5, 5, 5}, []float64{5, 5}); got != 1 {
+		t.Errorf("mannWhitneyU() of equal values = %v, want 1", got)
+	}
+}
@@ -0,0 +1,163 @@
+// Command complexity measures how pairing strategies scale, by pairing baskets of growing size
+// drawn from different numbers of kinds of sock and fitting the work done to O(n), O(n log n)
+// and O(n²).
+//
+// Usage:
+//
+//	complexity [-strategies names] [-sizes pairs] [-kinds kinds] [-runs n] [-seed n] [-csv]
+//
+// For each strategy and number of kinds, it prints the model that best fits the comparisons made
+// and the time taken, the R² of that fit, and the growth exponent k of work growing as n^k.
+// Fitting needs at least three different sizes. With -csv, it prints the measurements themselves
+// instead, from any sizes.
+//
+//	complexity -strategies sequential,surface -sizes 100,200,400,800 -kinds 1,100
+//
+// The exit status is 0 on success, 1 on error and 2 for bad usage.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/internal/strategies"
+)
+
+// Exit statuses.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with the given arguments, returning its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("complexity", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	names := flags.String("strategies", "random,sequential,sort-first,surface,bucket", "comma-separated pairing strategies: "+strategies.List())
+	sizes := flags.String("sizes", "50,100,200,400,800", "comma-separated numbers of pairs in the baskets")
+	kinds := flags.String("kinds", "1,10,100,1000", "comma-separated numbers of kinds of sock the baskets are drawn from")
+	runs := flags.Int("runs", 5, "number of baskets of each size and kind to average over")
+	seed := flags.Int64("seed", 1, "seed for the baskets and the random strategy")
+	asCSV := flags.Bool("csv", false, "print the measurements as CSV rather than the fitted models")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: complexity [-strategies names] [-sizes pairs] [-kinds kinds] [-runs n] [-seed n] [-csv]")
+		flags.PrintDefaults()
+	}
+	if flags.Parse(args) != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	e := sockpair.ComplexityExperiment{Runs: *runs, Rand: rand.New(rand.NewSource(*seed))}
+	chosen := strings.Split(*names, ",")
+	for _, name := range chosen {
+		strategy, ok := strategies.New(name, *seed)
+		if !ok {
+			fmt.Fprintf(stderr, "complexity: unknown strategy %q, want one of %s\n", name, strategies.List())
+			return exitUsage
+		}
+		e.Strategies = append(e.Strategies, strategy)
+	}
+	var err error
+	if e.Sizes, err = parseCounts(*sizes); err != nil {
+		fmt.Fprintf(stderr, "complexity: -sizes: %v\n", err)
+		return exitUsage
+	}
+	if !*asCSV && distinct(e.Sizes) < sockpair.MinComplexitySizes {
+		fmt.Fprintf(stderr, "complexity: -sizes: want at least %d different sizes to fit growth to, got %d\n", sockpair.MinComplexitySizes, distinct(e.Sizes))
+		return exitUsage
+	}
+	if e.Kinds, err = parseCounts(*kinds); err != nil {
+		fmt.Fprintf(stderr, "complexity: -kinds: %v\n", err)
+		return exitUsage
+	}
+
+	measurements, err := e.Run(context.Background())
+	if err == nil {
+		if *asCSV {
+			err = writeMeasurements(stdout, chosen, e, measurements)
+		} else {
+			var reports []sockpair.ComplexityReport
+			if reports, err = e.Analyze(measurements); err == nil {
+				err = writeReports(stdout, chosen, e, reports)
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "complexity: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// parseCounts parses a comma-separated list of positive integers.
+func parseCounts(s string) ([]int, error) {
+	counts := make([]int, 0)
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%q is not a positive integer", field)
+		}
+		counts = append(counts, n)
+	}
+	return counts, nil
+}
+
+// distinct returns the number of different counts.
+func distinct(counts []int) int {
+	seen := make(map[int]bool)
+	for _, n := range counts {
+		seen[n] = true
+	}
+	return len(seen)
+}
+
+// writeMeasurements writes the measurements as CSV, naming each strategy as it was chosen.
+func writeMeasurements(w io.Writer, names []string, e sockpair.ComplexityExperiment, measurements []sockpair.Measurement) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"strategy", "kinds", "socks", "comparisons", "ns"})
+	perStrategy := len(e.Kinds) * len(e.Sizes)
+	for i, m := range measurements {
+		cw.Write([]string{
+			names[i/perStrategy],
+			strconv.Itoa(m.Kinds),
+			strconv.Itoa(m.Socks),
+			strconv.FormatFloat(m.Comparisons, 'f', -1, 64),
+			strconv.FormatInt(m.Time.Nanoseconds(), 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeReports writes a table of the best fitting model of each report, naming each strategy as it
+// was chosen.
+func writeReports(w io.Writer, names []string, e sockpair.ComplexityExperiment, reports []sockpair.ComplexityReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "strategy\tkinds\tcomparisons\tR²\tk\ttime\tR²\tk")
+	for i, report := range reports {
+		comparisons, t := report.Comparisons[0], report.Time[0]
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%.3f\t%.2f\t%s\t%.3f\t%.2f\n",
+			names[i/len(e.Kinds)], report.Kinds,
+			comparisons.Model.Name, comparisons.RSquared, report.ComparisonsExponent,
+			t.Model.Name, t.RSquared, report.TimeExponent)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"-strategies", "sequential,surface", "-sizes", "10,20,40", "-kinds", "1,50", "-runs", "2"}
+	if status := run(args, &stdout, &stderr); status != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", status, exitOK, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("run() printed %d lines, want a header and 4 reports:\n%s", len(lines), stdout.String())
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "strategy kinds comparisons R² k time R² k" {
+		t.Errorf("header is %q", lines[0])
+	}
+	want := [][]string{{"sequential", "1"}, {"sequential", "50"}, {"surface", "1"}, {"surface", "50"}}
+	for i, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != want[i][0] || fields[1] != want[i][1] {
+			t.Errorf("report %d is %q, want %s with %s kinds", i, line, want[i][0], want[i][1])
+		}
+		if strings.Count(line, "O(") != 2 {
+			t.Errorf("report %d is %q, want a model for comparisons and for time", i, line)
+		}
+	}
+}
+
+func TestRun_csv(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"-csv", "-strategies", "random,bucket", "-sizes", "5,10", "-kinds", "3"}
+	if status := run(args, &stdout, &stderr); status != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", status, exitOK, stderr.String())
+	}
+
+	records, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatalf("run() printed invalid CSV: %v", err)
+	}
+	want := [][]string{
+		{"strategy", "kinds", "socks"},
+		{"random", "3", "10"},
+		{"random", "3", "20"},
+		{"bucket", "3", "10"},
+		{"bucket", "3", "20"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("run() printed %d records, want %d", len(records), len(want))
+	}
+	for i, record := range records {
+		if strings.Join(record[:3], ",") != strings.Join(want[i], ",") {
+			t.Errorf("record %d is %q, want it to start with %q", i, record, want[i])
+		}
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "unknown strategy", args: []string{"-strategies", "surface,folding"}, want: `unknown strategy "folding"`},
+		{name: "bad sizes", args: []string{"-sizes", "10,ten"}, want: `-sizes: "ten" is not a positive integer`},
+		{name: "one size", args: []string{"-sizes", "10"}, want: "-sizes: want at least 3 different sizes to fit growth to, got 1"},
+		{name: "repeated sizes", args: []string{"-sizes", "10,20,10"}, want: "-sizes: want at least 3 different sizes to fit growth to, got 2"},
+		{name: "zero kinds", args: []string{"-kinds", "0"}, want: `-kinds: "0" is not a positive integer`},
+		{name: "unknown flag", args: []string{"-size", "10"}, want: "flag provided but not defined"},
+		{name: "argument", args: []string{"basket.json"}, want: "usage: complexity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tt.args, &stdout, &stderr); status != exitUsage {
+				t.Errorf("run() = %d, want %d", status, exitUsage)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("run() printed %q, want it to contain %q", stderr.String(), tt.want)
+			}
+		})
+	}
+}
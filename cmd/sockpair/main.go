@@ -0,0 +1,202 @@
+// Command sockpair pairs a basket of socks read from a file, or from standard input.
+//
+// Usage:
+//
+//	sockpair [-strategy name] [-format json|csv|yaml|text] [-json] [-seed n] [file]
+//
+// The basket is read as JSON, CSV, YAML or the notation of sock_pair_in_golang.ParseSocks, chosen
+// by -format or else by the file's extension (.txt for the notation). A JSON basket is an array
+// of socks, such as
+//
+//	[{"color": "red", "pattern": "plain", "side": "left"}, {"color": "red", "pattern": "plain", "side": "right"}]
+//
+// and the same basket in the notation is "red/plain/L, red/plain/R".
+//
+// The pairs and orphans found are printed one per line in the notation, or as a JSON object
+// with -json.
+// The exit status is 0 when every sock was paired, 1 when orphans remain, and 2 on error.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/internal/strategies"
+)
+
+// Exit statuses.
+const (
+	exitPaired  = 0
+	exitOrphans = 1
+	exitError   = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// readers are the basket formats that can be read, by name.
+var readers = map[string]func(r io.Reader) sockpair.SockReader{
+	"json": func(r io.Reader) sockpair.SockReader { return sockpair.NewJSONSockReader(r) },
+	"csv":  func(r io.Reader) sockpair.SockReader { return sockpair.NewCSVSockReader(r) },
+	"yaml": func(r io.Reader) sockpair.SockReader { return sockpair.NewYAMLSockReader(r) },
+	"text": func(r io.Reader) sockpair.SockReader { return &notationReader{r: r} },
+}
+
+// notationReader reads a basket written in the notation of sock_pair_in_golang.ParseSocks.
+type notationReader struct {
+	r     io.Reader
+	socks sockpair.Socks
+	read  bool
+}
+
+func (r *notationReader) Read() (sockpair.Sock, error) {
+	if !r.read {
+		r.read = true
+		text, err := io.ReadAll(r.r)
+		if err != nil {
+			return sockpair.Sock{}, err
+		}
+		if r.socks, err = sockpair.ParseSocks(string(text)); err != nil {
+			return sockpair.Sock{}, err
+		}
+	}
+	if len(r.socks) == 0 {
+		return sockpair.Sock{}, io.EOF
+	}
+	s := r.socks[0]
+	r.socks = r.socks[1:]
+	return s, nil
+}
+
+// run runs the command with the given arguments, returning its exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("sockpair", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strategyName := flags.String("strategy", "surface", "pairing strategy: "+strategies.List())
+	format := flags.String("format", "", "basket format: csv, json, text or yaml (default from the file extension, or json)")
+	asJSON := flags.Bool("json", false, "print the result as JSON")
+	seed := flags.Int64("seed", 1, "seed for the random strategy")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: sockpair [-strategy name] [-format json|csv|yaml|text] [-json] [-seed n] [file]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	strategy, ok := strategies.New(*strategyName, *seed)
+	if !ok {
+		fmt.Fprintf(stderr, "sockpair: unknown strategy %q, want one of %s\n", *strategyName, strategies.List())
+		return exitError
+	}
+
+	basket, err := readBasket(flags.Arg(0), *format, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "sockpair: %v\n", err)
+		return exitError
+	}
+
+	res := sockpair.Pair(strategy, basket)
+	if *asJSON {
+		err = writeJSON(stdout, res)
+	} else {
+		err = writeText(stdout, res)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "sockpair: %v\n", err)
+		return exitError
+	}
+
+	if len(res.Orphans) > 0 {
+		return exitOrphans
+	}
+	return exitPaired
+}
+
+// readBasket reads a basket from the named file, or from stdin if name is empty or "-". An empty
+// format is chosen by the file's extension, or is JSON.
+func readBasket(name, format string, stdin io.Reader) (sockpair.Socks, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(name), ".")
+		switch format {
+		case "yml":
+			format = "yaml"
+		case "txt":
+			format = "text"
+		}
+		if _, ok := readers[format]; !ok {
+			format = "json"
+		}
+	}
+	newReader, ok := readers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q, want csv, json, text or yaml", format)
+	}
+
+	r := stdin
+	if name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	basket, err := sockpair.ReadSocks(newReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("reading basket: %w", err)
+	}
+	return basket, nil
+}
+
+func writeText(w io.Writer, res sockpair.PairingResult) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pairs (%d):\n", len(res.Pairs))
+	for _, pair := range res.Pairs {
+		fmt.Fprintf(&b, "  %s\n", sockpair.SockPairs{pair})
+	}
+	fmt.Fprintf(&b, "Orphans (%d):\n", len(res.Orphans))
+	for _, sock := range res.Orphans {
+		fmt.Fprintf(&b, "  %s\n", sock)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// jsonResult is how a PairingResult is written with -json.
+type jsonResult struct {
+	Pairs   sockpair.SockPairs `json:"pairs"`
+	Orphans sockpair.Socks     `json:"orphans"`
+	Stats   jsonStats          `json:"stats"`
+}
+
+// jsonStats is how PairingStats are written with -json.
+type jsonStats struct {
+	Comparisons     int `json:"comparisons"`
+	Draws           int `json:"draws"`
+	Removals        int `json:"removals"`
+	SurfacePeak     int `json:"surface_peak"`
+	Placements      int `json:"placements"`
+	Scans           int `json:"scans"`
+	SortComparisons int `json:"sort_comparisons"`
+	Steps           int `json:"steps"`
+	Passes          int `json:"passes"`
+}
+
+func writeJSON(w io.Writer, res sockpair.PairingResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonResult{Pairs: res.Pairs, Orphans: res.Orphans, Stats: jsonStats(res.Stats)})
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/internal/strategies"
+)
+
+const testBasket = `[
+	{"color": "red", "pattern": "plain", "side": "left"},
+	{"color": "blue", "pattern": "striped", "side": "right", "owner": "sam"},
+	{"color": "red", "pattern": "plain", "side": "right"}
+]`
+
+func TestRun(t *testing.T) {
+	for _, name := range strategies.Names() {
+		t.Run(name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run([]string{"-strategy", name}, strings.NewReader(testBasket), &stdout, &stderr)
+			if status != exitOrphans {
+				t.Errorf("run() = %d, want %d; stderr: %s", status, exitOrphans, stderr.String())
+			}
+			want := "Pairs (1):\n  red/plain/L + red/plain/R\nOrphans (1):\n  blue/striped/R/owner=sam\n"
+			if stdout.String() != want {
+				t.Errorf("run() printed %q, want %q", stdout.String(), want)
+			}
+		})
+	}
+}
+
+func TestRun_paired(t *testing.T) {
+	basket := `[{"color": "red", "side": "left", "id": "a"}, {"color": "red", "side": "right", "id": "b"}]`
+	var stdout, stderr bytes.Buffer
+	if status := run(nil, strings.NewReader(basket), &stdout, &stderr); status != exitPaired {
+		t.Errorf("run() = %d, want %d; stderr: %s", status, exitPaired, stderr.String())
+	}
+	if want := "Pairs (1):\n  red//L/id=a + red//R/id=b\nOrphans (0):\n"; stdout.String() != want {
+		t.Errorf("run() printed %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestRun_formats(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		data string
+	}{
+		{name: "csv", args: []string{"-format", "csv"}, data: "color,side\nred,left\nred,right\n"},
+		{name: "yaml", args: []string{"-format", "yaml"}, data: "- {color: red, side: left}\n- {color: red, side: right}\n"},
+		{name: "yaml flow sequence", args: []string{"-format", "yaml"}, data: "[{color: red, side: left}, {color: red, side: right}]\n"},
+		{name: "text", args: []string{"-format", "text"}, data: "red/plain/L\nred/plain/R\n"},
+		{name: "empty json", data: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tt.args, strings.NewReader(tt.data), &stdout, &stderr); status != exitPaired {
+				t.Errorf("run() = %d, want %d; stderr: %s", status, exitPaired, stderr.String())
+			}
+		})
+	}
+
+	// without -format, the format is chosen by the file extension
+	file := filepath.Join(t.TempDir(), "basket.yml")
+	if err := os.WriteFile(file, []byte("- {color: red, side: left}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{file}, strings.NewReader(""), &stdout, &stderr); status != exitOrphans {
+		t.Errorf("run() = %d, want %d; stderr: %s", status, exitOrphans, stderr.String())
+	}
+}
+
+func TestRun_json(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "basket.json")
+	if err := os.WriteFile(file, []byte(testBasket), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"-json", file}, strings.NewReader(""), &stdout, &stderr); status != exitOrphans {
+		t.Fatalf("run() = %d, want %d; stderr: %s", status, exitOrphans, stderr.String())
+	}
+	var got jsonResult
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("run() printed invalid JSON: %v", err)
+	}
+	wantOrphan := sockpair.Sock{Color: "blue", Pattern: "striped", Side: sockpair.Right, Owner: "sam"}
+	if len(got.Pairs) != 1 || len(got.Orphans) != 1 || got.Orphans[0] != wantOrphan || got.Stats.Draws != 3 {
+		t.Errorf("run() printed %+v", got)
+	}
+
+	var raw struct {
+		Stats map[string]int `json:"stats"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &raw); err != nil {
+		t.Fatalf("run() printed invalid JSON: %v", err)
+	}
+	for _, key := range []string{"comparisons", "draws", "surface_peak", "sort_comparisons", "passes"} {
+		if _, ok := raw.Stats[key]; !ok {
+			t.Errorf("run() printed stats %v, want a %q key", raw.Stats, key)
+		}
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+	}{
+		{name: "unknown strategy", args: []string{"-strategy", "folding"}, stdin: testBasket},
+		{name: "unknown flag", args: []string{"-color"}, stdin: testBasket},
+		{name: "too many files", args: []string{"a.json", "b.json"}, stdin: testBasket},
+		{name: "missing file", args: []string{filepath.Join(t.TempDir(), "missing.json")}},
+		{name: "no basket", stdin: ""},
+		{name: "unknown format", args: []string{"-format", "xml"}, stdin: testBasket},
+		{name: "invalid basket", stdin: `[{"color": "red", "side": "up"}]`},
+		{name: "invalid notation", args: []string{"-format", "text"}, stdin: "red/plain/Q"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr); status != exitError {
+				t.Errorf("run() = %d, want %d", status, exitError)
+			}
+			if stdout.Len() != 0 || stderr.Len() == 0 {
+				t.Errorf("run() printed %q to stdout and %q to stderr, want only an error", stdout.String(), stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package sock_pair_in_golang
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Lab is a color in the CIELAB color space, under a D65 white point. L is the lightness from 0
+// to 100, A runs from green to red and B from blue to yellow.
+type Lab struct {
+	L float64 `json:"l"`
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+}
+
+// Shade returns c as the Shade of a Sock.
+func (c Lab) Shade() Shade {
+	return Shade{lab: c, set: true}
+}
+
+// RGB is a color in the sRGB color space.
+type RGB struct {
+	R, G, B uint8
+}
+
+// ParseHex parses a color written in hex, such as "#000080" or its short form "#008".
+// The leading # is optional.
+func ParseHex(hex string) (RGB, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) != 6 {
+		return RGB{}, fmt.Errorf("invalid hex color %q", hex)
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid hex color %q", hex)
+	}
+	return RGB{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value)}, nil
+}
+
+// Hex returns c written in hex, such as "#000080".
+func (c RGB) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Lab converts c to the CIELAB color space.
+func (c RGB) Lab() Lab {
+	r, g, b := linearize(c.R), linearize(c.G), linearize(c.B)
+
+	// sRGB to CIE XYZ, relative to the D65 white point
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / 0.95047
+	y := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / 1.08883
+
+	fx, fy, fz := labF(x), labF(y), labF(z)
+	return Lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// Shade returns c as the Shade of a Sock.
+func (c RGB) Shade() Shade {
+	return c.Lab().Shade()
+}
+
+// linearize undoes the sRGB gamma curve on a color channel, returning it between 0 and 1.
+func linearize(channel uint8) float64 {
+	v := float64(channel) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func labF(t float64) float64 {
+	const delta = 6.0 / 29
+	if t > delta*delta*delta {
+		return math.Cbrt(t)
+	}
+	return t/(3*delta*delta) + 4.0/29
+}
+
+// Shade is the measured color of a Sock, for when its Color name alone is too coarse to pair by.
+// The zero Shade is unset.
+type Shade struct {
+	lab Lab
+	set bool
+}
+
+// Lab returns the color of the Shade, and whether it is set.
+func (s Shade) Lab() (Lab, bool) {
+	return s.lab, s.set
+}
+
+// shade returns the color of s, from its Shade if set or else from looking up its Color in the
+// named color table, and whether the color is known.
+func (s Sock) shade() (Lab, bool) {
+	if lab, ok := s.Shade.Lab(); ok {
+		return lab, true
+	}
+	if rgb, ok := NamedColor(s.Color); ok {
+		return rgb.Lab(), true
+	}
+	return Lab{}, false
+}
+
+// CIEDE2000 returns the CIEDE2000 color difference between a and b. A difference of around 1 is
+// the smallest most people can see side by side.
+func CIEDE2000(a, b Lab) float64 {
+	// adjust a* so that neutral colors have hues that behave
+	cBar := (math.Hypot(a.A, a.B) + math.Hypot(b.A, b.B)) / 2
+	cBar7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+math.Pow(25, 7))))
+	a1, a2 := (1+g)*a.A, (1+g)*b.A
+
+	c1, c2 := math.Hypot(a1, a.B), math.Hypot(a2, b.B)
+	h1, h2 := hueAngle(a.B, a1), hueAngle(b.B, a2)
+
+	dL := b.L - a.L
+	dC := c2 - c1
+	dh := 0.0
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(radians(dh/2))
+
+	lBar := (a.L + b.L) / 2
+	cBarPrime := (c1 + c2) / 2
+	hBar := h1 + h2
+	if c1*c2 != 0 {
+		switch {
+		case math.Abs(h1-h2) <= 180:
+			hBar /= 2
+		case hBar < 360:
+			hBar = (hBar + 360) / 2
+		default:
+			hBar = (hBar - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos(radians(hBar-30)) +
+		0.24*math.Cos(radians(2*hBar)) +
+		0.32*math.Cos(radians(3*hBar+6)) -
+		0.20*math.Cos(radians(4*hBar-63))
+	dTheta := 30 * math.Exp(-math.Pow((hBar-275)/25, 2))
+	cBarPrime7 := math.Pow(cBarPrime, 7)
+	rC := 2 * math.Sqrt(cBarPrime7/(cBarPrime7+math.Pow(25, 7)))
+	sL := 1 + 0.015*math.Pow(lBar-50, 2)/math.Sqrt(20+math.Pow(lBar-50, 2))
+	sC := 1 + 0.045*cBarPrime
+	sH := 1 + 0.015*cBarPrime*t
+	rT := -math.Sin(radians(2*dTheta)) * rC
+
+	l, c, h := dL/sL, dC/sC, dH/sH
+	return math.Sqrt(l*l + c*c + h*h + rT*c*h)
+}
+
+// hueAngle returns the angle of the point (a, b) in degrees, between 0 and 360.
+func hueAngle(b, a float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// ColorDistance returns the CIEDE2000 difference between the colors of a and b, and whether both
+// colors are known. A Sock's color is its Shade if set, or else its Color looked up with NamedColor.
+func ColorDistance(a, b Sock) (float64, bool) {
+	labA, okA := a.shade()
+	labB, okB := b.shade()
+	if !okA || !okB {
+		return 0, false
+	}
+	return CIEDE2000(labA, labB), true
+}
+
+// DefaultColorThreshold is the ColorDistanceMatcher threshold used when none is given. It is loose
+// enough to pair navy with dark blue, and a sock with its faded partner, but not navy with royal blue.
+const DefaultColorThreshold = 5.0
+
+// ColorDistanceMatcher pairs socks that Sock.IsMatchingPair would pair but for their colors, whose
+// colors are within Threshold of each other as measured by ColorDistance. Socks whose colors are not known
+// pair only by having the same Color.
+//
+// Closeness is not transitive, so navy may pair with dark blue and dark blue with medium blue
+// while navy and medium blue do not pair. MaximumMatchingPairingStrategy makes the best of this.
+type ColorDistanceMatcher struct {
+	// Threshold is the largest CIEDE2000 difference between the colors of a pair.
+	// Zero uses DefaultColorThreshold.
+	Threshold float64
+}
+
+func (m ColorDistanceMatcher) threshold() float64 {
+	if m.Threshold == 0 {
+		return DefaultColorThreshold
+	}
+	return m.Threshold
+}
+
+func (m ColorDistanceMatcher) MatchKey(s Sock) Sock {
+	// any two colors might be close enough, so socks are told apart by everything else
+	return AttributeMatcher{Attributes: identityAttributes &^ ColorAttribute}.MatchKey(s)
+}
+
+func (m ColorDistanceMatcher) IsMatchingPair(a, b Sock) bool {
+	if m.MatchKey(a) != m.MatchKey(b) || !a.Side.complements(b.Side) {
+		return false
+	}
+	if distance, ok := ColorDistance(a, b); ok {
+		return distance <= m.threshold()
+	}
+	return a.Color == b.Color
+}
+
+// Less orders socks by match key, then by hue, so that socks of similar colors tend to sort next
+// to each other. Colors too grey to have a hue come first, ordered by lightness, and unknown colors last.
+func (m ColorDistanceMatcher) Less(a, b Sock) bool {
+	if keyA, keyB := m.MatchKey(a), m.MatchKey(b); keyA != keyB {
+		return lessSock(keyA, keyB)
+	}
+
+	labA, okA := a.shade()
+	labB, okB := b.shade()
+	if okA != okB {
+		return okA
+	}
+	if okA {
+		if hueA, hueB := sortingHue(labA), sortingHue(labB); hueA != hueB {
+			return hueA < hueB
+		}
+		if labA.L != labB.L {
+			return labA.L < labB.L
+		}
+	}
+	return lessSock(a, b)
+}
+
+// neutralChroma is the chroma below which a color is considered grey.
+const neutralChroma = 5.0
+
+// sortingHue returns the hue of c, or -1 if c is grey.
+func sortingHue(c Lab) float64 {
+	if math.Hypot(c.A, c.B) < neutralChroma {
+		return -1
+	}
+	return hueAngle(c.B, c.A)
+}
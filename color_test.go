@@ -0,0 +1,181 @@
+package sock_pair_in_golang_test
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestCIEDE2000(t *testing.T) {
+	// from Sharma, Wu and Dalal's test data for the CIEDE2000 color difference formula
+	tests := []struct {
+		a, b sockpair.Lab
+		want float64
+	}{
+		{sockpair.Lab{L: 50, A: 2.6772, B: -79.7751}, sockpair.Lab{L: 50, A: 0, B: -82.7485}, 2.0425},
+		{sockpair.Lab{L: 50, A: 3.1571, B: -77.2803}, sockpair.Lab{L: 50, A: 0, B: -82.7485}, 2.8615},
+		{sockpair.Lab{L: 50, A: 2.8361, B: -74.0200}, sockpair.Lab{L: 50, A: 0, B: -82.7485}, 3.4412},
+		{sockpair.Lab{L: 50, A: -1.3802, B: -84.2814}, sockpair.Lab{L: 50, A: 0, B: -82.7485}, 1.0000},
+		{sockpair.Lab{L: 50, A: 0, B: 0}, sockpair.Lab{L: 50, A: -1, B: 2}, 2.3669},
+		{sockpair.Lab{L: 50, A: -1, B: 2}, sockpair.Lab{L: 50, A: 0, B: 0}, 2.3669},
+		{sockpair.Lab{L: 50, A: 2.5, B: 0}, sockpair.Lab{L: 73, A: 25, B: -18}, 27.1492},
+		{sockpair.Lab{L: 50, A: 2.5, B: 0}, sockpair.Lab{L: 61, A: -5, B: 29}, 22.8977},
+		{sockpair.Lab{L: 50, A: 2.5, B: 0}, sockpair.Lab{L: 56, A: -27, B: -3}, 31.9030},
+		{sockpair.Lab{L: 50, A: 2.5, B: 0}, sockpair.Lab{L: 58, A: 24, B: 15}, 19.4535},
+		{sockpair.Lab{L: 50, A: 2.5, B: 0}, sockpair.Lab{L: 50, A: 3.1736, B: 0.5854}, 1.0000},
+		{sockpair.Lab{L: 60.2574, A: -34.0099, B: 36.2677}, sockpair.Lab{L: 60.4626, A: -34.1751, B: 39.4387}, 1.2644},
+		{sockpair.Lab{L: 63.0109, A: -31.0961, B: -5.8663}, sockpair.Lab{L: 62.8187, A: -29.7946, B: -4.0864}, 1.2630},
+		{sockpair.Lab{L: 2.0776, A: 0.0795, B: -1.1350}, sockpair.Lab{L: 0.9033, A: -0.0636, B: -0.5514}, 0.9082},
+		{sockpair.Lab{L: 50, A: 10, B: 10}, sockpair.Lab{L: 50, A: 10, B: 10}, 0},
+	}
+	for _, tt := range tests {
+		if got := sockpair.CIEDE2000(tt.a, tt.b); math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("CIEDE2000(%v, %v) = %.4f, want %.4f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRGB_Lab(t *testing.T) {
+	tests := []struct {
+		rgb  sockpair.RGB
+		want sockpair.Lab
+	}{
+		{sockpair.RGB{R: 0, G: 0, B: 0}, sockpair.Lab{L: 0, A: 0, B: 0}},
+		{sockpair.RGB{R: 255, G: 255, B: 255}, sockpair.Lab{L: 100, A: 0, B: 0}},
+		{sockpair.RGB{R: 255, G: 0, B: 0}, sockpair.Lab{L: 53.24, A: 80.09, B: 67.20}},
+		{sockpair.RGB{R: 0, G: 0, B: 128}, sockpair.Lab{L: 12.98, A: 47.51, B: -64.70}},
+	}
+	for _, tt := range tests {
+		got := tt.rgb.Lab()
+		if math.Abs(got.L-tt.want.L) > 0.01 || math.Abs(got.A-tt.want.A) > 0.01 || math.Abs(got.B-tt.want.B) > 0.01 {
+			t.Errorf("%v.Lab() = %v, want %v", tt.rgb, got, tt.want)
+		}
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		hex     string
+		want    sockpair.RGB
+		wantErr bool
+	}{
+		{"#000080", sockpair.RGB{R: 0, G: 0, B: 128}, false},
+		{"FFa500", sockpair.RGB{R: 255, G: 165, B: 0}, false},
+		{"#f80", sockpair.RGB{R: 255, G: 136, B: 0}, false},
+		{"#12345", sockpair.RGB{}, true},
+		{"#00008g", sockpair.RGB{}, true},
+		{"", sockpair.RGB{}, true},
+	}
+	for _, tt := range tests {
+		got, err := sockpair.ParseHex(tt.hex)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHex(%q) error = %v, wantErr %v", tt.hex, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseHex(%q) = %v, want %v", tt.hex, got, tt.want)
+		}
+	}
+}
+
+func TestRGB_Hex(t *testing.T) {
+	c := sockpair.RGB{R: 255, G: 165, B: 0}
+	if got := c.Hex(); got != "#ffa500" {
+		t.Errorf("%v.Hex() = %q, want %q", c, got, "#ffa500")
+	}
+}
+
+func TestNamedColor(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   sockpair.RGB
+		wantOk bool
+	}{
+		{"navy", sockpair.RGB{R: 0, G: 0, B: 128}, true},
+		{"Dark Blue", sockpair.RGB{R: 0, G: 0, B: 139}, true},
+		{"light-grey", sockpair.RGB{R: 211, G: 211, B: 211}, true},
+		{"sock monster green", sockpair.RGB{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := sockpair.NamedColor(tt.name)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("NamedColor(%q) = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestColorDistanceMatcher(t *testing.T) {
+	faded, err := sockpair.ParseHex("#10108a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	navyLeft := sockpair.Sock{Color: "navy", Pattern: "plain", Side: sockpair.Left}
+	navyRight := sockpair.Sock{Color: "navy", Pattern: "plain", Side: sockpair.Right}
+	darkBlueRight := sockpair.Sock{Color: "dark blue", Pattern: "plain", Side: sockpair.Right}
+	fadedRight := sockpair.Sock{Color: "navy", Pattern: "plain", Side: sockpair.Right, Shade: faded.Shade()}
+	royalBlueRight := sockpair.Sock{Color: "royal blue", Pattern: "plain", Side: sockpair.Right}
+	navyStripedRight := sockpair.Sock{Color: "navy", Pattern: "striped", Side: sockpair.Right}
+	argyleLeft := sockpair.Sock{Color: "argyle mix", Pattern: "plain", Side: sockpair.Left}
+	argyleRight := sockpair.Sock{Color: "argyle mix", Pattern: "plain", Side: sockpair.Right}
+	labRight := sockpair.Sock{Color: "mystery", Pattern: "plain", Side: sockpair.Right, Shade: sockpair.Lab{L: 12.98, A: 47.51, B: -64.70}.Shade()}
+
+	tests := []struct {
+		name    string
+		matcher sockpair.ColorDistanceMatcher
+		a, b    sockpair.Sock
+		want    bool
+	}{
+		{"same name", sockpair.ColorDistanceMatcher{}, navyLeft, navyRight, true},
+		{"similar names", sockpair.ColorDistanceMatcher{}, navyLeft, darkBlueRight, true},
+		{"similar names, tight threshold", sockpair.ColorDistanceMatcher{Threshold: 1}, navyLeft, darkBlueRight, false},
+		{"faded shade", sockpair.ColorDistanceMatcher{}, navyLeft, fadedRight, true},
+		{"lab shade", sockpair.ColorDistanceMatcher{}, navyLeft, labRight, true},
+		{"different colors", sockpair.ColorDistanceMatcher{}, navyLeft, royalBlueRight, false},
+		{"different colors, loose threshold", sockpair.ColorDistanceMatcher{Threshold: 40}, navyLeft, royalBlueRight, true},
+		{"different patterns", sockpair.ColorDistanceMatcher{}, navyLeft, navyStripedRight, false},
+		{"same side", sockpair.ColorDistanceMatcher{}, navyRight, darkBlueRight, false},
+		{"unknown colors, same name", sockpair.ColorDistanceMatcher{}, argyleLeft, argyleRight, true},
+		{"unknown color", sockpair.ColorDistanceMatcher{}, navyLeft, argyleRight, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.IsMatchingPair(tt.a, tt.b); got != tt.want {
+				t.Errorf("%v.IsMatchingPair(%v, %v) = %v, want %v", tt.matcher, tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.matcher.IsMatchingPair(tt.b, tt.a); got != tt.want {
+				t.Errorf("%v.IsMatchingPair(%v, %v) = %v, want %v", tt.matcher, tt.b, tt.a, got, tt.want)
+			}
+			if tt.want && tt.matcher.MatchKey(tt.a) != tt.matcher.MatchKey(tt.b) {
+				t.Errorf("%v.MatchKey() differs for matching socks %v and %v", tt.matcher, tt.a, tt.b)
+			}
+		})
+	}
+}
+
+func TestSockPairingStrategy_PairSocks_colorDistance(t *testing.T) {
+	basket := sockpair.Socks{
+		{Color: "navy", Pattern: "plain", Side: sockpair.Left},
+		{Color: "crimson", Pattern: "plain", Side: sockpair.Left},
+		{Color: "dark blue", Pattern: "plain", Side: sockpair.Right},
+		{Color: "Crimson", Pattern: "plain", Side: sockpair.Right},
+		{Color: "gold", Pattern: "plain", Side: sockpair.Right},
+	}
+	wantOrphans := sockpair.Socks{{Color: "gold", Pattern: "plain", Side: sockpair.Right}}
+
+	matcher := sockpair.ColorDistanceMatcher{}
+	for _, strategy := range []sockpair.SockPairingStrategy{
+		sockpair.RandomPairingStrategy{Rand: newRand(t), Matcher: matcher},
+		sockpair.SequentialPairingStrategy{Matcher: matcher},
+		sockpair.SortFirstPairingStrategy{Matcher: matcher},
+		sockpair.SurfacePairingStrategy{Matcher: matcher},
+		sockpair.MaximumMatchingPairingStrategy{Matcher: matcher},
+	} {
+		got := strategy.PairSocks(basket)
+		sort.Sort(got.Orphans)
+		if len(got.Pairs) != 2 || len(got.Orphans) != 1 || got.Orphans[0] != wantOrphans[0] {
+			t.Errorf("%T.PairSocks() = %v, %v, want 2 pairs and orphans %v", strategy, got.Pairs, got.Orphans, wantOrphans)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package sock_pair_in_golang
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ComplexityExperiment measures how the work done by pairing strategies grows with the size of
+// the basket, by pairing baskets of each size drawn from each number of kinds of Sock.
+type ComplexityExperiment struct {
+	Strategies []SockPairingStrategy
+	// Sizes are the numbers of pairs in the baskets.
+	Sizes []int
+	// Kinds are the numbers of different kinds of Sock the baskets are drawn from, each kind
+	// being a color that pairs only with itself. A basket with few kinds holds many socks that
+	// look alike.
+	Kinds []int
+	// Runs is the number of baskets of each size and kind paired, whose measurements are averaged.
+	// Zero means one run.
+	Runs int
+	// Rand is the source of the baskets, so an experiment can be reproduced from its seed. A nil
+	// Rand uses the shared source from the math/rand package.
+	Rand *rand.Rand
+}
+
+// Measurement is the average work a strategy did to pair baskets of one size and kind.
+type Measurement struct {
+	Strategy SockPairingStrategy
+	// Socks is the number of socks in each basket.
+	Socks int
+	// Kinds is the number of kinds of Sock the baskets were drawn from.
+	Kinds int
+	// Comparisons is the number of comparisons made, including those made while sorting.
+	Comparisons float64
+	// Time is the time taken to pair a basket.
+	Time time.Duration
+}
+
+// Run pairs a basket of each size and kind with each strategy, returning the measurements
+// grouped by strategy, then kind, then size. It stops early if ctx is done, returning the
+// measurements taken so far along with the context's error.
+func (e ComplexityExperiment) Run(ctx context.Context) ([]Measurement, error) {
+	runs := e.Runs
+	if runs < 1 {
+		runs = 1
+	}
+
+	measurements := make([]Measurement, 0, len(e.Strategies)*len(e.Kinds)*len(e.Sizes))
+	for _, strategy := range e.Strategies {
+		for _, kinds := range e.Kinds {
+			variety := Variety{Colors: make([]string, kinds), Patterns: []string{"plain"}}
+			for i := range variety.Colors {
+				variety.Colors[i] = "color-" + strconv.Itoa(i+1)
+			}
+
+			for _, size := range e.Sizes {
+				m := Measurement{Strategy: strategy, Socks: 2 * size, Kinds: kinds}
+				for run := 0; run < runs; run++ {
+					basket, _ := BasketGenerator{Variety: variety, Pairs: size, Rand: e.Rand}.Generate()
+					start := time.Now()
+					res, err := PairContext(ctx, strategy, basket)
+					if err != nil {
+						return measurements, err
+					}
+					m.Time += time.Since(start)
+					m.Comparisons += float64(res.Stats.Comparisons + res.Stats.SortComparisons)
+				}
+				m.Time /= time.Duration(runs)
+				m.Comparisons /= float64(runs)
+				measurements = append(measurements, m)
+			}
+		}
+	}
+	return measurements, nil
+}
+
+// ComplexityModel is a function describing how work grows with the size of the input.
+type ComplexityModel struct {
+	// Name is the model in big O notation, such as "O(n)".
+	Name string
+	// Growth returns the work for an input of size n, up to a constant factor.
+	Growth func(n float64) float64
+}
+
+// The models the measurements of a ComplexityExperiment are fitted to.
+var (
+	Linear       = ComplexityModel{Name: "O(n)", Growth: func(n float64) float64 { return n }}
+	Linearithmic = ComplexityModel{Name: "O(n log n)", Growth: func(n float64) float64 { return n * math.Log2(n) }}
+	Quadratic    = ComplexityModel{Name: "O(n²)", Growth: func(n float64) float64 { return n * n }}
+)
+
+// ComplexityModels are the models FitComplexity fits measurements to.
+var ComplexityModels = []ComplexityModel{Linear, Linearithmic, Quadratic}
+
+// Fit is how well a ComplexityModel describes some measurements.
+type Fit struct {
+	Model ComplexityModel
+	// Coefficient is the constant factor c in the least squares fit of y = c·Growth(n).
+	Coefficient float64
+	// RSquared is the coefficient of determination of the fit, which is 1 for a perfect fit
+	// and falls as the fit gets worse, below zero for a fit worse than a flat line at the mean.
+	RSquared float64
+}
+
+// FitComplexity fits the work ys done for inputs of sizes ns to each of ComplexityModels,
+// returning the fits best first.
+func FitComplexity(ns, ys []float64) []Fit {
+	mean := 0.0
+	for _, y := range ys {
+		mean += y
+	}
+	mean /= float64(len(ys))
+	total := 0.0
+	for _, y := range ys {
+		total += (y - mean) * (y - mean)
+	}
+
+	fits := make([]Fit, 0, len(ComplexityModels))
+	for _, model := range ComplexityModels {
+		// the least squares fit of y = c·g through the origin has c = Σg·y / Σg²
+		gy, gg := 0.0, 0.0
+		for i, n := range ns {
+			g := model.Growth(n)
+			gy += g * ys[i]
+			gg += g * g
+		}
+		fit := Fit{Model: model}
+		if gg > 0 {
+			fit.Coefficient = gy / gg
+		}
+
+		residual := 0.0
+		for i, n := range ns {
+			r := ys[i] - fit.Coefficient*model.Growth(n)
+			residual += r * r
+		}
+		switch {
+		case total > 0:
+			fit.RSquared = 1 - residual/total
+		case residual == 0:
+			fit.RSquared = 1
+		}
+		fits = append(fits, fit)
+	}
+
+	sort.SliceStable(fits, func(i, j int) bool {
+		return fits[i].RSquared > fits[j].RSquared
+	})
+	return fits
+}
+
+// GrowthExponent returns the slope of the least squares line through the logs of ys against the
+// logs of ns, which is k for work growing as n^k. Positive values only are used, and it returns
+// NaN if fewer than two remain.
+func GrowthExponent(ns, ys []float64) float64 {
+	xs, ls := make([]float64, 0, len(ns)), make([]float64, 0, len(ys))
+	for i, n := range ns {
+		if n > 0 && ys[i] > 0 {
+			xs = append(xs, math.Log(n))
+			ls = append(ls, math.Log(ys[i]))
+		}
+	}
+	if len(xs) < 2 {
+		return math.NaN()
+	}
+
+	meanX, meanL := 0.0, 0.0
+	for i := range xs {
+		meanX += xs[i]
+		meanL += ls[i]
+	}
+	meanX /= float64(len(xs))
+	meanL /= float64(len(ls))
+	cov, varX := 0.0, 0.0
+	for i := range xs {
+		cov += (xs[i] - meanX) * (ls[i] - meanL)
+		varX += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if varX == 0 {
+		return math.NaN()
+	}
+	return cov / varX
+}
+
+// ComplexityReport is how the work done by a strategy grows with the size of baskets drawn from
+// one number of kinds of Sock.
+type ComplexityReport struct {
+	Strategy SockPairingStrategy
+	Kinds    int
+	// Comparisons and Time are the fits of the comparisons made and the time taken, best first.
+	Comparisons, Time []Fit
+	// ComparisonsExponent and TimeExponent are the growth exponents of the comparisons made
+	// and the time taken.
+	ComparisonsExponent, TimeExponent float64
+}
+
+// MinComplexitySizes is the fewest different basket sizes Analyze fits models to. Through one or
+// two points every model fits all but perfectly, so the best fit would say nothing.
+const MinComplexitySizes = 3
+
+// Analyze fits the measurements of each strategy and number of kinds, as returned by Run, to
+// each of ComplexityModels. It returns an error if e has fewer than MinComplexitySizes
+// different Sizes.
+func (e ComplexityExperiment) Analyze(measurements []Measurement) ([]ComplexityReport, error) {
+	distinct := make(map[int]bool)
+	for _, size := range e.Sizes {
+		distinct[size] = true
+	}
+	if len(distinct) < MinComplexitySizes {
+		return nil, fmt.Errorf("fitting growth needs at least %d different basket sizes, got %d", MinComplexitySizes, len(distinct))
+	}
+
+	reports := make([]ComplexityReport, 0)
+	for i := 0; i < len(measurements); i += len(e.Sizes) {
+		group := measurements[i:]
+		if len(group) > len(e.Sizes) {
+			group = group[:len(e.Sizes)]
+		}
+
+		ns := make([]float64, 0, len(group))
+		comparisons := make([]float64, 0, len(group))
+		times := make([]float64, 0, len(group))
+		for _, m := range group {
+			ns = append(ns, float64(m.Socks))
+			comparisons = append(comparisons, m.Comparisons)
+			times = append(times, float64(m.Time))
+		}
+		reports = append(reports, ComplexityReport{
+			Strategy:            group[0].Strategy,
+			Kinds:               group[0].Kinds,
+			Comparisons:         FitComplexity(ns, comparisons),
+			Time:                FitComplexity(ns, times),
+			ComparisonsExponent: GrowthExponent(ns, comparisons),
+			TimeExponent:        GrowthExponent(ns, times),
+		})
+	}
+	return reports, nil
+}
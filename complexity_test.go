@@ -0,0 +1,169 @@
+package sock_pair_in_golang_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestFitComplexity(t *testing.T) {
+	ns := []float64{10, 20, 40, 80, 160, 320}
+	tests := []struct {
+		model sockpair.ComplexityModel
+		want  string
+	}{
+		{model: sockpair.Linear, want: "O(n)"},
+		{model: sockpair.Linearithmic, want: "O(n log n)"},
+		{model: sockpair.Quadratic, want: "O(n²)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			ys := make([]float64, len(ns))
+			for i, n := range ns {
+				ys[i] = 3 * tt.model.Growth(n)
+			}
+
+			fits := sockpair.FitComplexity(ns, ys)
+			if len(fits) != len(sockpair.ComplexityModels) {
+				t.Fatalf("got %d fits, want %d", len(fits), len(sockpair.ComplexityModels))
+			}
+			best := fits[0]
+			if best.Model.Name != tt.want {
+				t.Errorf("best fit is %s, want %s", best.Model.Name, tt.want)
+			}
+			if math.Abs(best.Coefficient-3) > 1e-9 {
+				t.Errorf("coefficient is %v, want 3", best.Coefficient)
+			}
+			if math.Abs(best.RSquared-1) > 1e-9 {
+				t.Errorf("R² is %v, want 1", best.RSquared)
+			}
+			for _, fit := range fits[1:] {
+				if fit.RSquared >= best.RSquared {
+					t.Errorf("%s has R² %v, not below the best fit's %v", fit.Model.Name, fit.RSquared, best.RSquared)
+				}
+			}
+		})
+	}
+}
+
+func TestGrowthExponent(t *testing.T) {
+	ns := []float64{10, 20, 40, 80}
+	tests := []struct {
+		name string
+		ys   []float64
+		want float64
+	}{
+		{name: "constant", ys: []float64{5, 5, 5, 5}, want: 0},
+		{name: "linear", ys: []float64{20, 40, 80, 160}, want: 1},
+		{name: "quadratic", ys: []float64{100, 400, 1600, 6400}, want: 2},
+		{name: "skipsZeros", ys: []float64{0, 400, 1600, 0}, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sockpair.GrowthExponent(ns, tt.ys); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("GrowthExponent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := sockpair.GrowthExponent(ns, []float64{0, 0, 0, 7}); !math.IsNaN(got) {
+		t.Errorf("GrowthExponent() of a single positive point = %v, want NaN", got)
+	}
+}
+
+func TestComplexityExperiment_Run(t *testing.T) {
+	e := sockpair.ComplexityExperiment{
+		Strategies: []sockpair.SockPairingStrategy{
+			sockpair.SequentialPairingStrategy{},
+			sockpair.SortFirstPairingStrategy{},
+			sockpair.SurfacePairingStrategy{},
+		},
+		Sizes: []int{25, 50, 100, 200, 400},
+		Kinds: []int{1, 1000},
+		Runs:  3,
+		Rand:  newRand(t),
+	}
+	measurements, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() returned %v", err)
+	}
+	if want := len(e.Strategies) * len(e.Kinds) * len(e.Sizes); len(measurements) != want {
+		t.Fatalf("got %d measurements, want %d", len(measurements), want)
+	}
+	for i, m := range measurements {
+		if want := 2 * e.Sizes[i%len(e.Sizes)]; m.Socks != want {
+			t.Errorf("measurement %d has %d socks, want %d", i, m.Socks, want)
+		}
+		if want := e.Kinds[i/len(e.Sizes)%len(e.Kinds)]; m.Kinds != want {
+			t.Errorf("measurement %d has %d kinds, want %d", i, m.Kinds, want)
+		}
+		if m.Comparisons <= 0 {
+			t.Errorf("measurement %d made %v comparisons", i, m.Comparisons)
+		}
+	}
+
+	reports, err := e.Analyze(measurements)
+	if err != nil {
+		t.Fatalf("Analyze() returned %v", err)
+	}
+	if want := len(e.Strategies) * len(e.Kinds); len(reports) != want {
+		t.Fatalf("got %d reports, want %d", len(reports), want)
+	}
+	// with a kind for almost every pair, each strategy does the work its algorithm promises
+	want := map[sockpair.SockPairingStrategy]string{
+		sockpair.SequentialPairingStrategy{}: "O(n²)",
+		sockpair.SortFirstPairingStrategy{}:  "O(n log n)",
+		sockpair.SurfacePairingStrategy{}:    "O(n)",
+	}
+	for i, report := range reports {
+		if report.Strategy != e.Strategies[i/len(e.Kinds)] || report.Kinds != e.Kinds[i%len(e.Kinds)] {
+			t.Errorf("report %d is of %T with %d kinds", i, report.Strategy, report.Kinds)
+		}
+		if report.Kinds != 1000 {
+			continue
+		}
+		if got := report.Comparisons[0].Model.Name; got != want[report.Strategy] {
+			t.Errorf("%T: comparisons fit %s best, want %s", report.Strategy, got, want[report.Strategy])
+		}
+	}
+}
+
+func TestComplexityExperiment_Analyze_tooFewSizes(t *testing.T) {
+	for _, sizes := range [][]int{nil, {10}, {10, 20}, {10, 20, 10, 20}} {
+		e := sockpair.ComplexityExperiment{
+			Strategies: []sockpair.SockPairingStrategy{sockpair.SurfacePairingStrategy{}},
+			Sizes:      sizes,
+			Kinds:      []int{5},
+			Rand:       newRand(t),
+		}
+		measurements, err := e.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Run() returned %v", err)
+		}
+		if reports, err := e.Analyze(measurements); err == nil {
+			t.Errorf("Analyze() with sizes %v returned %d reports, want an error", sizes, len(reports))
+		}
+	}
+}
+
+func TestComplexityExperiment_Run_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	e := sockpair.ComplexityExperiment{
+		Strategies: []sockpair.SockPairingStrategy{sockpair.SurfacePairingStrategy{}},
+		Sizes:      []int{10, 20},
+		Kinds:      []int{5},
+		Rand:       newRand(t),
+	}
+	measurements, err := e.Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() returned %v, want %v", err, context.Canceled)
+	}
+	if len(measurements) != 0 {
+		t.Errorf("Run() returned %d measurements, want none", len(measurements))
+	}
+}
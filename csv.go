@@ -0,0 +1,259 @@
+package sock_pair_in_golang
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvHeader is the header row of the CSV encoding of socks. Each Sock is written as a row, with
+// its Side and Wear written as text, an empty cell for an unspecified attribute, and its Shade,
+// if set, as its L, A and B coordinates separated by spaces, such as "13 47.5 -64.5".
+var csvHeader = []string{"id", "color", "pattern", "side", "shade", "size", "owner", "material", "brand", "wear"}
+
+// csvPairColumn is the column that numbers the pair each Sock belongs to in the CSV encoding of
+// SockPairs, which otherwise matches that of Socks.
+const csvPairColumn = "pair"
+
+// CSVSockReader reads socks one at a time from CSV, as written by CSVSockWriter. The first row
+// must be a header naming the columns, which may come in any order and may leave out attributes
+// that are unspecified. A pair column, as written by SockPairs.MarshalCSV, is ignored.
+type CSVSockReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+func NewCSVSockReader(r io.Reader) *CSVSockReader {
+	return &CSVSockReader{r: csv.NewReader(r)}
+}
+
+func (r *CSVSockReader) Read() (Sock, error) {
+	s, _, err := r.read()
+	return s, err
+}
+
+// read returns the next Sock along with its pair cell, which is empty if there is no pair column.
+func (r *CSVSockReader) read() (Sock, string, error) {
+	if r.columns == nil {
+		if err := r.readHeader(); err != nil {
+			return Sock{}, "", err
+		}
+	}
+
+	record, err := r.r.Read()
+	if err != nil {
+		return Sock{}, "", err
+	}
+	cell := func(column string) string {
+		if i, ok := r.columns[column]; ok {
+			return record[i]
+		}
+		return ""
+	}
+
+	s := Sock{
+		ID:       cell("id"),
+		Color:    cell("color"),
+		Pattern:  cell("pattern"),
+		Size:     cell("size"),
+		Owner:    cell("owner"),
+		Material: cell("material"),
+		Brand:    cell("brand"),
+	}
+	if side := cell("side"); side != "" {
+		if err := s.Side.UnmarshalText([]byte(side)); err != nil {
+			return Sock{}, "", r.errorAt("side", err)
+		}
+	}
+	if wear := cell("wear"); wear != "" {
+		if err := s.Wear.UnmarshalText([]byte(wear)); err != nil {
+			return Sock{}, "", r.errorAt("wear", err)
+		}
+	}
+	if shade := cell("shade"); shade != "" {
+		lab, err := parseLab(shade)
+		if err != nil {
+			return Sock{}, "", r.errorAt("shade", err)
+		}
+		s.Shade = lab.Shade()
+	}
+	return s, cell(csvPairColumn), nil
+}
+
+func (r *CSVSockReader) readHeader() error {
+	header, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+	for _, column := range append(csvHeader, csvPairColumn) {
+		known[column] = true
+	}
+	r.columns = make(map[string]int)
+	for i, column := range header {
+		if !known[column] {
+			line, _ := r.r.FieldPos(i)
+			return fmt.Errorf("line %d: unknown column %q", line, column)
+		}
+		r.columns[column] = i
+	}
+	return nil
+}
+
+// errorAt returns err along with the position of the given column in the last row read.
+func (r *CSVSockReader) errorAt(column string, err error) error {
+	line, col := r.r.FieldPos(r.columns[column])
+	return fmt.Errorf("line %d, column %d: %w", line, col, err)
+}
+
+// parseLab parses a color written as its L, A and B coordinates separated by spaces.
+func parseLab(text string) (Lab, error) {
+	fields := strings.Fields(text)
+	if len(fields) != 3 {
+		return Lab{}, fmt.Errorf("invalid shade %q, want L, A and B separated by spaces", text)
+	}
+	coordinates := make([]float64, len(fields))
+	for i, field := range fields {
+		f, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return Lab{}, fmt.Errorf("invalid shade %q, want L, A and B separated by spaces", text)
+		}
+		coordinates[i] = f
+	}
+	return Lab{L: coordinates[0], A: coordinates[1], B: coordinates[2]}, nil
+}
+
+func formatLab(c Lab) string {
+	return strings.Join([]string{
+		strconv.FormatFloat(c.L, 'g', -1, 64),
+		strconv.FormatFloat(c.A, 'g', -1, 64),
+		strconv.FormatFloat(c.B, 'g', -1, 64),
+	}, " ")
+}
+
+// CSVSockWriter writes socks one at a time as CSV, starting with a header row.
+type CSVSockWriter struct {
+	w           *csv.Writer
+	header      []string
+	wroteHeader bool
+}
+
+func NewCSVSockWriter(w io.Writer) *CSVSockWriter {
+	return &CSVSockWriter{w: csv.NewWriter(w), header: csvHeader}
+}
+
+func (w *CSVSockWriter) Write(s Sock) error {
+	return w.write(nil, s)
+}
+
+// write writes s as a row, after the cells of any columns that come before csvHeader.
+func (w *CSVSockWriter) write(prefix []string, s Sock) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	side, err := s.Side.MarshalText()
+	if err != nil {
+		return err
+	}
+	wear := ""
+	if s.Wear != UnknownWear {
+		text, err := s.Wear.MarshalText()
+		if err != nil {
+			return err
+		}
+		wear = string(text)
+	}
+	shade := ""
+	if lab, ok := s.Shade.Lab(); ok {
+		shade = formatLab(lab)
+	}
+	record := append(prefix, s.ID, s.Color, s.Pattern, string(side), shade, s.Size, s.Owner, s.Material, s.Brand, wear)
+	return w.w.Write(record)
+}
+
+func (w *CSVSockWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	return w.w.Write(w.header)
+}
+
+// Close writes the header row if no Sock was written, and flushes the rows written.
+func (w *CSVSockWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// MarshalCSV returns the socks as CSV, one row per Sock after a header row.
+func (s Socks) MarshalCSV() ([]byte, error) {
+	var b bytes.Buffer
+	if err := WriteSocks(NewCSVSockWriter(&b), s); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalCSV parses socks written as CSV.
+func (s *Socks) UnmarshalCSV(data []byte) error {
+	socks, err := ReadSocks(NewCSVSockReader(bytes.NewReader(data)))
+	if err != nil {
+		return err
+	}
+	*s = socks
+	return nil
+}
+
+// MarshalCSV returns the pairs as CSV, written as socks with a leading pair column numbering
+// the pair, from 1, that each Sock belongs to.
+func (p SockPairs) MarshalCSV() ([]byte, error) {
+	var b bytes.Buffer
+	w := NewCSVSockWriter(&b)
+	w.header = append([]string{csvPairColumn}, csvHeader...)
+	for i, pair := range p {
+		for _, s := range pair {
+			if err := w.write([]string{strconv.Itoa(i + 1)}, s); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalCSV parses pairs written as CSV by MarshalCSV. The socks of each pair must be on
+// consecutive rows, and each pair must hold two socks.
+func (p *SockPairs) UnmarshalCSV(data []byte) error {
+	r := NewCSVSockReader(bytes.NewReader(data))
+	pairs := make([]Socks, 0)
+	last := ""
+	for {
+		s, pair, err := r.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := r.columns[csvPairColumn]; !ok {
+			return fmt.Errorf("pairs must have a %q column", csvPairColumn)
+		}
+
+		if len(pairs) == 0 || pair != last {
+			pairs = append(pairs, make(Socks, 0, 2))
+			last = pair
+		}
+		pairs[len(pairs)-1] = append(pairs[len(pairs)-1], s)
+	}
+	return p.set(pairs)
+}
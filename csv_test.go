@@ -0,0 +1,130 @@
+package sock_pair_in_golang_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestSocks_MarshalCSV(t *testing.T) {
+	data, err := encodingBasket.MarshalCSV()
+	if err != nil {
+		t.Fatalf("Socks.MarshalCSV() error = %v", err)
+	}
+	var got sockpair.Socks
+	if err := got.UnmarshalCSV(data); err != nil {
+		t.Fatalf("Socks.UnmarshalCSV() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, encodingBasket) {
+		t.Errorf("Socks.UnmarshalCSV() = %v, want %v", got, encodingBasket)
+	}
+
+	data, err = sockpair.Socks{encodingBasket[2]}.MarshalCSV()
+	if err != nil {
+		t.Fatalf("Socks.MarshalCSV() error = %v", err)
+	}
+	want := "id,color,pattern,side,shade,size,owner,material,brand,wear\n" +
+		"rfid-0042,navy,\"ribbed, with a heel\",right,13 47.5 -64.5,child,sam,wool,acme,worn out\n"
+	if string(data) != want {
+		t.Errorf("Socks.MarshalCSV() = %q, want %q", data, want)
+	}
+}
+
+func TestSocks_UnmarshalCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    sockpair.Socks
+		wantErr string
+	}{
+		{
+			"columns in any order",
+			"side,color\nleft,red\n,blue\n",
+			sockpair.Socks{{Color: "red", Side: sockpair.Left}, {Color: "blue", Side: sockpair.Either}},
+			"",
+		},
+		{"header only", "color,pattern\n", sockpair.Socks{}, ""},
+		{"empty", "", sockpair.Socks{}, ""},
+		{"unknown column", "color,colour\nred,red\n", nil, "line 1: unknown column \"colour\""},
+		{"invalid side", "color,side\nred,left\nred,up\n", nil, "line 3, column 5: invalid side \"up\""},
+		{"invalid shade", "color,shade\nred,13 47.5\n", nil, "line 2, column 5: invalid shade"},
+		{"missing cell", "color,side\nred\n", nil, "wrong number of fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got sockpair.Socks
+			err := got.UnmarshalCSV([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Socks.UnmarshalCSV() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Socks.UnmarshalCSV() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Socks.UnmarshalCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSockPairs_MarshalCSV(t *testing.T) {
+	data, err := encodingPairs.MarshalCSV()
+	if err != nil {
+		t.Fatalf("SockPairs.MarshalCSV() error = %v", err)
+	}
+	var got sockpair.SockPairs
+	if err := got.UnmarshalCSV(data); err != nil {
+		t.Fatalf("SockPairs.UnmarshalCSV() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, encodingPairs) {
+		t.Errorf("SockPairs.UnmarshalCSV() = %v, want %v", got, encodingPairs)
+	}
+
+	// pairs read as a basket are just their socks
+	var basket sockpair.Socks
+	if err := basket.UnmarshalCSV(data); err != nil {
+		t.Fatalf("Socks.UnmarshalCSV() error = %v", err)
+	}
+	if !reflect.DeepEqual(basket, encodingBasket) {
+		t.Errorf("Socks.UnmarshalCSV() = %v, want %v", basket, encodingBasket)
+	}
+}
+
+func TestSockPairs_UnmarshalCSV_errors(t *testing.T) {
+	for _, data := range []string{
+		"color,side\nred,left\nred,right\n",
+		"pair,color,side\n1,red,left\n1,red,right\n2,blue,left\n",
+		"pair,color,side\n1,red,left\n1,red,right\n1,blue,left\n",
+	} {
+		var got sockpair.SockPairs
+		if err := got.UnmarshalCSV([]byte(data)); err == nil {
+			t.Errorf("SockPairs.UnmarshalCSV(%q) returned no error", data)
+		}
+	}
+}
+
+func TestCSVSockWriter(t *testing.T) {
+	basket := sockpair.AssignIDs(sockpair.GenerateVariedSocks(sockpair.Variety{
+		Colors:   []string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		Patterns: []string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		Owners:   []string{"sam", "alex"},
+	}, 3, false), "sock")
+
+	var b bytes.Buffer
+	if err := sockpair.WriteSocks(sockpair.NewCSVSockWriter(&b), basket); err != nil {
+		t.Fatalf("WriteSocks() error = %v", err)
+	}
+	got, err := sockpair.ReadSocks(sockpair.NewCSVSockReader(&b))
+	if err != nil {
+		t.Fatalf("ReadSocks() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, basket) {
+		t.Errorf("ReadSocks() = %v, want %v", got, basket)
+	}
+}
@@ -0,0 +1,62 @@
+package sock_pair_in_golang
+
+import (
+	"sort"
+	"time"
+)
+
+// EffortModel estimates how long a person would take to pair a basket from the work counted in
+// its PairingStats, so strategies can be compared by the time they would cost a person rather
+// than by how fast a computer runs them.
+type EffortModel struct {
+	// Draw is the time taken to pick a Sock out of the basket.
+	Draw time.Duration
+	// Comparison is the time taken to look at two socks and decide whether they pair.
+	Comparison time.Duration
+	// Placement is the time taken to lay a Sock out on the surface.
+	Placement time.Duration
+	// Scan is the time taken to look over the surface for a Sock's partner.
+	Scan time.Duration
+	// SortStep is the time taken by each comparison made while sorting the basket, including
+	// moving the socks into place.
+	SortStep time.Duration
+}
+
+// DefaultEffortModel is a rough guess at the time a person takes over each step of pairing socks.
+var DefaultEffortModel = EffortModel{
+	Draw:       time.Second,
+	Comparison: 500 * time.Millisecond,
+	Placement:  time.Second,
+	Scan:       time.Second,
+	SortStep:   2 * time.Second,
+}
+
+// Estimate returns the time a person would take to do the work counted in stats.
+func (m EffortModel) Estimate(stats PairingStats) time.Duration {
+	return time.Duration(stats.Draws)*m.Draw +
+		time.Duration(stats.Comparisons)*m.Comparison +
+		time.Duration(stats.Placements)*m.Placement +
+		time.Duration(stats.Scans)*m.Scan +
+		time.Duration(stats.SortComparisons)*m.SortStep
+}
+
+// EffortReport is the estimated time a person would take to pair a basket with Strategy.
+type EffortReport struct {
+	Strategy SockPairingStrategy
+	Result   PairingResult
+	Time     time.Duration
+}
+
+// Compare pairs the basket with each of the strategies, returning their reports quickest first.
+func (m EffortModel) Compare(basket Socks, strategies ...SockPairingStrategy) []EffortReport {
+	reports := make([]EffortReport, 0, len(strategies))
+	for _, strategy := range strategies {
+		res := strategy.PairSocks(basket)
+		reports = append(reports, EffortReport{Strategy: strategy, Result: res, Time: m.Estimate(res.Stats)})
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Time < reports[j].Time
+	})
+	return reports
+}
@@ -0,0 +1,65 @@
+package sock_pair_in_golang_test
+
+import (
+	"testing"
+	"time"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestEffortModel_Estimate(t *testing.T) {
+	model := sockpair.EffortModel{
+		Draw:       time.Second,
+		Comparison: 2 * time.Second,
+		Placement:  3 * time.Second,
+		Scan:       4 * time.Second,
+		SortStep:   5 * time.Second,
+	}
+	stats := sockpair.PairingStats{
+		Comparisons:     1,
+		Draws:           2,
+		Removals:        10,
+		SurfacePeak:     10,
+		Placements:      3,
+		Scans:           4,
+		SortComparisons: 5,
+		Steps:           10,
+	}
+	if got, want := model.Estimate(stats), 54*time.Second; got != want {
+		t.Errorf("EffortModel.Estimate() = %v, want %v", got, want)
+	}
+	if got := (sockpair.EffortModel{}).Estimate(stats); got != 0 {
+		t.Errorf("EffortModel{}.Estimate() = %v, want 0", got)
+	}
+}
+
+func TestEffortModel_Compare(t *testing.T) {
+	basket := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		2,
+		false,
+	), newRand(t))
+
+	// comparing socks is all that costs anything, and laying them out saves the most comparisons
+	model := sockpair.EffortModel{Comparison: time.Second}
+	reports := model.Compare(basket,
+		sockpair.SequentialPairingStrategy{},
+		sockpair.SurfacePairingStrategy{},
+		sockpair.RandomPairingStrategy{Rand: newRand(t)},
+	)
+	if len(reports) != 3 {
+		t.Fatalf("EffortModel.Compare() = %d reports, want 3", len(reports))
+	}
+	if _, ok := reports[0].Strategy.(sockpair.SurfacePairingStrategy); !ok {
+		t.Errorf("EffortModel.Compare() quickest = %T, want SurfacePairingStrategy", reports[0].Strategy)
+	}
+	for i, report := range reports {
+		if want := model.Estimate(report.Result.Stats); report.Time != want {
+			t.Errorf("EffortModel.Compare()[%d].Time = %v, want %v", i, report.Time, want)
+		}
+		if i > 0 && report.Time < reports[i-1].Time {
+			t.Errorf("EffortModel.Compare() is not ordered quickest first: %v before %v", reports[i-1].Time, report.Time)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package sock_pair_in_golang
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MarshalText writes a Side as "left", "right" or "either".
+func (s Side) MarshalText() ([]byte, error) {
+	if s.String() == "unknown" {
+		return nil, fmt.Errorf("invalid side %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses a Side written as "left", "right" or "either".
+func (s *Side) UnmarshalText(text []byte) error {
+	for _, side := range []Side{Left, Right, Either} {
+		if string(text) == side.String() {
+			*s = side
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid side %q", text)
+}
+
+// MarshalText writes a Wear as "unknown", "like new", "worn" or "worn out".
+func (w Wear) MarshalText() ([]byte, error) {
+	if w.String() == "invalid" {
+		return nil, fmt.Errorf("invalid wear %d", int(w))
+	}
+	return []byte(w.String()), nil
+}
+
+// UnmarshalText parses a Wear written as "unknown", "like new", "worn" or "worn out".
+func (w *Wear) UnmarshalText(text []byte) error {
+	for _, wear := range []Wear{UnknownWear, LikeNew, Worn, WornOut} {
+		if string(text) == wear.String() {
+			*w = wear
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid wear %q", text)
+}
+
+// encodedSock is the JSON and YAML encoding of a Sock, leaving out unspecified attributes. A Sock
+// is written as an object such as {"color": "red", "pattern": "plain", "side": "left"}, with its
+// Side and Wear written as text and its Shade, if set, as an object of its L, A and B coordinates.
+type encodedSock struct {
+	ID       string `json:"id,omitempty" yaml:"id,omitempty"`
+	Color    string `json:"color" yaml:"color"`
+	Pattern  string `json:"pattern" yaml:"pattern"`
+	Side     Side   `json:"side" yaml:"side"`
+	Shade    *Lab   `json:"shade,omitempty" yaml:"shade,omitempty,flow"`
+	Size     string `json:"size,omitempty" yaml:"size,omitempty"`
+	Owner    string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Material string `json:"material,omitempty" yaml:"material,omitempty"`
+	Brand    string `json:"brand,omitempty" yaml:"brand,omitempty"`
+	Wear     Wear   `json:"wear,omitempty" yaml:"wear,omitempty"`
+}
+
+func newEncodedSock(s Sock) encodedSock {
+	es := encodedSock{
+		ID:       s.ID,
+		Color:    s.Color,
+		Pattern:  s.Pattern,
+		Side:     s.Side,
+		Size:     s.Size,
+		Owner:    s.Owner,
+		Material: s.Material,
+		Brand:    s.Brand,
+		Wear:     s.Wear,
+	}
+	if lab, ok := s.Shade.Lab(); ok {
+		es.Shade = &lab
+	}
+	return es
+}
+
+func (es encodedSock) sock() Sock {
+	s := Sock{
+		ID:       es.ID,
+		Color:    es.Color,
+		Pattern:  es.Pattern,
+		Side:     es.Side,
+		Size:     es.Size,
+		Owner:    es.Owner,
+		Material: es.Material,
+		Brand:    es.Brand,
+		Wear:     es.Wear,
+	}
+	if es.Shade != nil {
+		s.Shade = es.Shade.Shade()
+	}
+	return s
+}
+
+func (s Sock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newEncodedSock(s))
+}
+
+func (s *Sock) UnmarshalJSON(data []byte) error {
+	var es encodedSock
+	if err := json.Unmarshal(data, &es); err != nil {
+		return err
+	}
+	*s = es.sock()
+	return nil
+}
+
+// UnmarshalJSON parses pairs written as an array of two Sock arrays, as SockPairs are written
+// by default, returning an error if a pair does not hold two socks.
+func (p *SockPairs) UnmarshalJSON(data []byte) error {
+	var pairs []Socks
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return err
+	}
+	return p.set(pairs)
+}
+
+// set sets p to pairs, returning an error if a pair does not hold two socks.
+func (p *SockPairs) set(pairs []Socks) error {
+	for i, pair := range pairs {
+		if len(pair) != 2 {
+			return fmt.Errorf("pair %d holds %d socks, want 2", i+1, len(pair))
+		}
+	}
+	*p = make(SockPairs, len(pairs))
+	for i, pair := range pairs {
+		(*p)[i] = pair
+	}
+	return nil
+}
+
+// SockReader reads a basket one Sock at a time, so large baskets need not be held in memory.
+type SockReader interface {
+	// Read returns the next Sock, or io.EOF once every Sock has been read.
+	Read() (Sock, error)
+}
+
+// SockWriter writes a basket one Sock at a time.
+type SockWriter interface {
+	Write(s Sock) error
+	// Close finishes writing the basket. It does not close the underlying writer.
+	Close() error
+}
+
+// ReadSocks reads every Sock from r.
+func ReadSocks(r SockReader) (Socks, error) {
+	socks := make(Socks, 0)
+	for {
+		s, err := r.Read()
+		if err == io.EOF {
+			return socks, nil
+		}
+		if err != nil {
+			return socks, err
+		}
+		socks = append(socks, s)
+	}
+}
+
+// WriteSocks writes every Sock to w, then closes it.
+func WriteSocks(w SockWriter, socks Socks) error {
+	for _, s := range socks {
+		if err := w.Write(s); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// JSONSockReader reads socks one at a time from a JSON array, as written by json.Marshal or
+// JSONSockWriter.
+type JSONSockReader struct {
+	dec     *json.Decoder
+	started bool
+	done    bool
+}
+
+func NewJSONSockReader(r io.Reader) *JSONSockReader {
+	return &JSONSockReader{dec: json.NewDecoder(r)}
+}
+
+func (r *JSONSockReader) Read() (Sock, error) {
+	if !r.started {
+		token, err := r.dec.Token()
+		if err == io.EOF {
+			// unlike a reader that has reached the end of the array, there was no array at all
+			return Sock{}, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return Sock{}, err
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return Sock{}, errors.New("socks must be written as a JSON array")
+		}
+		r.started = true
+	}
+
+	if r.done {
+		return Sock{}, io.EOF
+	}
+	if !r.dec.More() {
+		if _, err := r.dec.Token(); err != nil {
+			return Sock{}, unexpectedEOF(err)
+		}
+		r.done = true
+		// only whitespace may follow the array
+		token, err := r.dec.Token()
+		if err == io.EOF {
+			return Sock{}, io.EOF
+		}
+		if err != nil {
+			return Sock{}, err
+		}
+		return Sock{}, fmt.Errorf("unexpected %v after the JSON array of socks", token)
+	}
+	var s Sock
+	err := r.dec.Decode(&s)
+	return s, unexpectedEOF(err)
+}
+
+// unexpectedEOF returns io.ErrUnexpectedEOF in place of io.EOF, and of the syntax error newer
+// versions of encoding/json report, for input that ends part way through a JSON array.
+func unexpectedEOF(err error) error {
+	var syntaxErr *json.SyntaxError
+	if err == io.EOF || errors.As(err, &syntaxErr) && syntaxErr.Error() == "unexpected end of JSON input" {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// JSONSockWriter writes socks one at a time as a JSON array, with one Sock per line.
+type JSONSockWriter struct {
+	w     io.Writer
+	count int
+}
+
+func NewJSONSockWriter(w io.Writer) *JSONSockWriter {
+	return &JSONSockWriter{w: w}
+}
+
+func (w *JSONSockWriter) Write(s Sock) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if w.count == 0 {
+		separator = "[\n"
+	}
+	w.count++
+	_, err = io.WriteString(w.w, separator+string(data))
+	return err
+}
+
+func (w *JSONSockWriter) Close() error {
+	end := "\n]\n"
+	if w.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(w.w, end)
+	return err
+}
@@ -0,0 +1,202 @@
+package sock_pair_in_golang_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestSock_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		sock sockpair.Sock
+		want string
+	}{
+		{
+			"color and pattern only",
+			sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Left},
+			`{"color":"red","pattern":"plain","side":"left"}`,
+		},
+		{
+			"every attribute",
+			sockpair.Sock{
+				ID:       "rfid-0042",
+				Color:    "navy",
+				Pattern:  "ribbed",
+				Side:     sockpair.Right,
+				Shade:    sockpair.Lab{L: 13, A: 47.5, B: -64.5}.Shade(),
+				Size:     "child",
+				Owner:    "sam",
+				Material: "wool",
+				Brand:    "acme",
+				Wear:     sockpair.WornOut,
+			},
+			`{"id":"rfid-0042","color":"navy","pattern":"ribbed","side":"right","shade":{"l":13,"a":47.5,"b":-64.5},` +
+				`"size":"child","owner":"sam","material":"wool","brand":"acme","wear":"worn out"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.sock)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+
+			var sock sockpair.Sock
+			if err := json.Unmarshal(got, &sock); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(sock, tt.sock) {
+				t.Errorf("json.Unmarshal() = %v, want %v", sock, tt.sock)
+			}
+		})
+	}
+}
+
+func TestSock_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    sockpair.Sock
+		wantErr bool
+	}{
+		{"missing side", `{"color":"red","pattern":"plain"}`, sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Either}, false},
+		{"invalid side", `{"color":"red","side":"up"}`, sockpair.Sock{}, true},
+		{"invalid wear", `{"color":"red","wear":"shredded"}`, sockpair.Sock{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got sockpair.Sock
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("json.Unmarshal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// encodingBasket holds socks with every attribute, and with none, to round trip through each encoding.
+var encodingBasket = sockpair.Socks{
+	{Color: "red", Pattern: "plain", Side: sockpair.Left},
+	{Color: "red", Pattern: "plain", Side: sockpair.Right},
+	{
+		ID:       "rfid-0042",
+		Color:    "navy",
+		Pattern:  "ribbed, with a heel",
+		Side:     sockpair.Right,
+		Shade:    sockpair.Lab{L: 13, A: 47.5, B: -64.5}.Shade(),
+		Size:     "child",
+		Owner:    "sam",
+		Material: "wool",
+		Brand:    "acme",
+		Wear:     sockpair.WornOut,
+	},
+	{ID: "rfid-0043", Color: "navy", Pattern: "ribbed, with a heel", Side: sockpair.Left, Size: "child", Owner: "sam", Material: "wool", Brand: "acme"},
+	{},
+	{Color: "\"quoted\"", Pattern: "- dashed: yes", Side: sockpair.Either},
+}
+
+// encodingPairs holds the socks of encodingBasket as pairs.
+var encodingPairs = sockpair.SockPairs{encodingBasket[0:2], encodingBasket[2:4], encodingBasket[4:6]}
+
+func TestSocks_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(encodingBasket)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got sockpair.Socks
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, encodingBasket) {
+		t.Errorf("json.Unmarshal() = %v, want %v", got, encodingBasket)
+	}
+}
+
+func TestSockPairs_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(encodingPairs)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got sockpair.SockPairs
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, encodingPairs) {
+		t.Errorf("json.Unmarshal() = %v, want %v", got, encodingPairs)
+	}
+
+	if err := json.Unmarshal([]byte(`[[{"color":"red"}]]`), &got); err == nil {
+		t.Errorf("json.Unmarshal() of a pair with one sock returned no error")
+	}
+}
+
+func TestJSONSockWriter(t *testing.T) {
+	basket := sockpair.AssignIDs(sockpair.GenerateVariedSocks(sockpair.Variety{
+		Colors:   []string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		Patterns: []string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		Wears:    []sockpair.Wear{sockpair.LikeNew, sockpair.Worn},
+	}, 3, false), "sock")
+
+	for _, socks := range []sockpair.Socks{basket, encodingBasket, {}} {
+		var b bytes.Buffer
+		if err := sockpair.WriteSocks(sockpair.NewJSONSockWriter(&b), socks); err != nil {
+			t.Fatalf("WriteSocks() error = %v", err)
+		}
+
+		// what is written streaming is read back whole, and the other way around
+		var whole sockpair.Socks
+		if err := json.Unmarshal(b.Bytes(), &whole); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		if !reflect.DeepEqual(whole, socks) {
+			t.Errorf("json.Unmarshal() = %v, want %v", whole, socks)
+		}
+		data, err := json.Marshal(socks)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		streamed, err := sockpair.ReadSocks(sockpair.NewJSONSockReader(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("ReadSocks() error = %v", err)
+		}
+		if !reflect.DeepEqual(streamed, socks) {
+			t.Errorf("ReadSocks() = %v, want %v", streamed, socks)
+		}
+	}
+}
+
+func TestJSONSockReader_errors(t *testing.T) {
+	for _, data := range []string{
+		`{"color":"red"}`,
+		`[{"side":"up"}]`,
+		`[{"color":"red"}}`,
+		`[{"color":"red"}] garbage`,
+		`[{"color":"red"}] [{"color":"blue"}]`,
+		`[] 1`,
+	} {
+		if _, err := sockpair.ReadSocks(sockpair.NewJSONSockReader(strings.NewReader(data))); err == nil {
+			t.Errorf("ReadSocks(%s) returned no error", data)
+		}
+	}
+}
+
+func TestJSONSockReader_truncated(t *testing.T) {
+	for _, data := range []string{``, `[`, `[{"col`, `[{"color":"red"}`, `[{"color":"red"},`} {
+		if _, err := sockpair.ReadSocks(sockpair.NewJSONSockReader(strings.NewReader(data))); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadSocks(%s) error = %v, want %v", data, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package sock_pair_in_golang
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// Rules describe how items of type T pair up, so that the same strategies used for socks can pair
+// gloves, shoes or earrings.
+//
+// Two items pair when they share a match key of type K and Complements reports that they complete
+// one another. Items with different match keys never pair, which lets strategies group the items
+// they have seen by key rather than comparing against every one of them.
+type Rules[T any, K comparable] struct {
+	// Key returns the match key of an item, such as the color and pattern of a Sock.
+	Key func(item T) K
+	// Complements reports whether a and b, which share a match key, complete a pair.
+	Complements func(a, b T) bool
+	// Side returns the Side an item is made for. It may be set only when items sharing a match
+	// key complete a pair exactly when their sides complement one another, and lets PairSurface
+	// and PairSortFirst find a partner by side instead of comparing against every item with the
+	// key. A nil Side compares items with Complements.
+	Side func(item T) Side
+	// Less orders items so that those sharing a match key sort next to each other. It is only
+	// needed by PairSortFirst.
+	Less func(a, b T) bool
+	// Order returns the items of a pair in their canonical order, such as left then right.
+	// A nil Order leaves each pair in the order it was found.
+	Order func(a, b T) (T, T)
+	// Cost returns how poor a pair a and b make, such as how far apart their colors are, as a
+	// finite number. It is only used by PairMaximumMatching; a nil Cost treats every pair as
+	// equally good.
+	Cost func(a, b T) float64
+}
+
+// matches reports whether a and b pair.
+func (r Rules[T, K]) matches(a, b T) bool {
+	return r.Key(a) == r.Key(b) && r.Complements(a, b)
+}
+
+// pair returns a and b as a pair in their canonical order.
+func (r Rules[T, K]) pair(a, b T) []T {
+	if r.Order != nil {
+		a, b = r.Order(a, b)
+	}
+	return []T{a, b}
+}
+
+// Result is the outcome of pairing a basket of items of type T. It mirrors PairingResult, with
+// each pair held as a two item slice in the order given by Rules.Order.
+type Result[T any] struct {
+	Pairs       [][]T
+	Orphans     []T
+	Unprocessed []T
+	Stats       PairingStats
+}
+
+// PairingOptions configure the generic strategies. Options that do not apply to a strategy are ignored.
+type PairingOptions struct {
+	// Rand is the source of the random draws made by PairRandom. A nil Rand uses the shared
+	// source from the math/rand package.
+	Rand *rand.Rand
+	// InPlace pairs the items within the caller's basket rather than a copy of it, saving an
+	// allocation but leaving the basket in an unspecified order.
+	InPlace bool
+	// Capacity is the number of items PairBoundedSurface can lay out at once.
+	Capacity int
+	// Eviction decides what PairBoundedSurface does with an item that pairs with nothing on a
+	// full surface. A nil Eviction sets the item aside.
+	Eviction EvictionPolicy
+}
+
+// countingSorter sorts items by a less function, counting the comparisons made.
+type countingSorter[T any] struct {
+	items       []T
+	less        func(a, b T) bool
+	comparisons *int
+}
+
+func (s countingSorter[T]) Len() int {
+	return len(s.items)
+}
+
+func (s countingSorter[T]) Less(i, j int) bool {
+	*s.comparisons++
+	return s.less(s.items[i], s.items[j])
+}
+
+func (s countingSorter[T]) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+}
+
+// randomIndex returns a random index in [0,n) from r, or from the shared source if r is nil.
+func randomIndex(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.Intn(n)
+}
+
+// PairRandom pairs items by grabbing the first item in the basket, then drawing random items
+// from the basket until one pairs with it.
+func PairRandom[T any, K comparable](ctx context.Context, items []T, rules Rules[T, K], opts PairingOptions) (Result[T], error) {
+	pairedItems := make([][]T, 0)
+	orphanedItems := make([]T, 0)
+	stats := PairingStats{}
+
+	// check for empty basket
+	if len(items) == 0 {
+		return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Stats: stats}, nil
+	}
+
+	// check for single item basket
+	if len(items) == 1 {
+		stats.Draws++
+		return Result[T]{Pairs: pairedItems, Orphans: append(orphanedItems, items[0]), Stats: stats}, nil
+	}
+
+	items = prepareBasket(items, opts.InPlace)
+	itemToPair := items[0]
+	items = items[1:]
+	stats.Draws++
+	reassignAndCheckForOrphans := false
+
+	comparisonCount := 0
+	for len(items) > 0 {
+		if err := ctx.Err(); err != nil {
+			unprocessedItems := append([]T{itemToPair}, items...)
+			return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Unprocessed: unprocessedItems, Stats: stats}, err
+		}
+
+		stats.Steps++
+		// generate a random index
+		randomIdx := randomIndex(opts.Rand, len(items))
+		foundItem := items[randomIdx]
+		stats.Draws++
+
+		stats.Comparisons++
+		if rules.matches(itemToPair, foundItem) {
+			pairedItems = append(pairedItems, rules.pair(itemToPair, foundItem))
+
+			// remove the matched item
+			stats.Removals++
+			if res, err := removeSockFromBasket(items, randomIdx); err == nil {
+				items = res
+			}
+
+			reassignAndCheckForOrphans = true
+		} else if comparisonCount > len(items)*len(items) {
+			orphanedItems = append(orphanedItems, itemToPair)
+			reassignAndCheckForOrphans = true
+		}
+
+		comparisonCount++
+
+		if reassignAndCheckForOrphans {
+			if len(items) > 1 {
+				// assign a new itemToPair to compare against
+				itemToPair = items[0]
+				stats.Draws++
+				// remove the new itemToPair from the unpaired items
+				stats.Removals++
+				if res, err := removeSockFromBasket(items, 0); err == nil {
+					items = res
+				}
+				// reset, so we can compare our itemToPair against all the unpaired items
+				reassignAndCheckForOrphans = false
+				comparisonCount = 0
+			} else {
+				// last item is an orphan
+				orphanedItems = append(orphanedItems, items...)
+				break
+			}
+		}
+	}
+
+	return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Stats: stats}, nil
+}
+
+// PairSequential pairs items by grabbing the first item in the basket, then comparing it to each
+// subsequent item in the basket until one pairs with it.
+func PairSequential[T any, K comparable](ctx context.Context, items []T, rules Rules[T, K], opts PairingOptions) (Result[T], error) {
+	pairedItems := make([][]T, 0)
+	orphanedItems := make([]T, 0)
+	stats := PairingStats{}
+
+	// check for empty basket
+	if len(items) == 0 {
+		return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Stats: stats}, nil
+	}
+
+	// check for single item basket
+	if len(items) == 1 {
+		stats.Draws++
+		return Result[T]{Pairs: pairedItems, Orphans: append(orphanedItems, items[0]), Stats: stats}, nil
+	}
+
+	items = prepareBasket(items, opts.InPlace)
+	itemToPair := items[0]
+	items = items[1:]
+	stats.Draws++
+	reassignAndCheckForOrphans := false
+
+	i := 0
+	for len(items) > 0 {
+		if err := ctx.Err(); err != nil {
+			unprocessedItems := append([]T{itemToPair}, items...)
+			return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Unprocessed: unprocessedItems, Stats: stats}, err
+		}
+
+		stats.Steps++
+		stats.Draws++
+		stats.Comparisons++
+		if rules.matches(itemToPair, items[i]) {
+			pairedItems = append(pairedItems, rules.pair(itemToPair, items[i]))
+
+			// remove the matched item
+			stats.Removals++
+			if res, err := removeSockFromBasket(items, i); err == nil {
+				items = res
+			}
+
+			reassignAndCheckForOrphans = true
+		} else {
+			i++
+
+			// check if we've encountered an orphaned item that is not at the bottom of the basket
+			if i >= len(items) {
+				orphanedItems = append(orphanedItems, itemToPair)
+				reassignAndCheckForOrphans = true
+			}
+		}
+
+		if reassignAndCheckForOrphans {
+			if len(items) > 1 {
+				// assign a new itemToPair to compare against
+				itemToPair = items[0]
+				stats.Draws++
+				// remove the new itemToPair from the unpaired items
+				stats.Removals++
+				if res, err := removeSockFromBasket(items, 0); err == nil {
+					items = res
+				}
+				// reset the index, so we can compare our itemToPair against all the unpaired items
+				i = 0
+				reassignAndCheckForOrphans = false
+			} else {
+				// last item is an orphan
+				orphanedItems = append(orphanedItems, items...)
+				break
+			}
+		}
+	}
+
+	return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Stats: stats}, nil
+}
+
+// PairSortFirst pairs items by sorting the basket with Rules.Less, so that items sharing a match
+// key sort into a run, then pairing each item with an unpaired item before it in its run that it
+// complements. It returns an error if rules has no Less.
+func PairSortFirst[T any, K comparable](ctx context.Context, items []T, rules Rules[T, K], opts PairingOptions) (Result[T], error) {
+	pairedItems := make([][]T, 0)
+	orphanedItems := make([]T, 0)
+	stats := PairingStats{}
+
+	if rules.Less == nil {
+		return Result[T]{}, errors.New("sort first pairing requires Rules.Less")
+	}
+
+	// every item is taken out of the basket to be sorted
+	items = prepareBasket(items, opts.InPlace)
+	stats.Draws += len(items)
+	sort.Sort(countingSorter[T]{items, rules.Less, &stats.SortComparisons})
+
+	// the unpaired items of the current run, which need not alternate: a run of lefts may be
+	// followed by a run of rights
+	run := &pile[T, K]{}
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			unprocessedItems := append(run.items(), items[i:]...)
+			return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Unprocessed: unprocessedItems, Stats: stats}, err
+		}
+
+		stats.Steps++
+		// an item that starts a new run leaves the unpaired items of the last one orphaned
+		if i > 0 && rules.Key(items[i-1]) != rules.Key(item) {
+			orphanedItems = append(orphanedItems, run.items()...)
+			run = &pile[T, K]{}
+		}
+
+		if candidate, ok := run.take(rules, item, &stats); ok {
+			pairedItems = append(pairedItems, rules.pair(candidate, item))
+		} else {
+			run.add(rules, item)
+		}
+	}
+	orphanedItems = append(orphanedItems, run.items()...)
+
+	return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Stats: stats}, nil
+}
+
+// PairSurface pairs items by placing each one on a surface, grouped by match key, and checking
+// the surface for a match each time a new item is pulled from the basket. It never modifies the basket.
+func PairSurface[T any, K comparable](ctx context.Context, items []T, rules Rules[T, K], opts PairingOptions) (Result[T], error) {
+	pairedItems := make([][]T, 0)
+	stats := PairingStats{}
+	surface := newSurface(rules, &stats)
+
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			// items still on the surface may yet be matched by those left in the basket
+			unprocessedItems := append(surface.clear(), items[i:]...)
+			return Result[T]{Pairs: pairedItems, Orphans: make([]T, 0), Unprocessed: unprocessedItems, Stats: stats}, err
+		}
+
+		stats.Steps++
+		stats.Draws++
+		// check if a matching item already exists on the surface
+		if matchingItem, ok := surface.take(item); ok {
+			pairedItems = append(pairedItems, rules.pair(item, matchingItem))
+		} else {
+			surface.place(item)
+		}
+	}
+
+	// collect remaining orphaned items
+	return Result[T]{Pairs: pairedItems, Orphans: surface.clear(), Stats: stats}, nil
+}
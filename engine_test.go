@@ -0,0 +1,140 @@
+package sock_pair_in_golang_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/sockpairtest"
+)
+
+type hand int
+
+const (
+	leftHand hand = iota
+	rightHand
+	// anyHand is a glove that fits either hand, such as a disposable nitrile glove.
+	anyHand
+)
+
+// glove is paired with the same strategies as a Sock, using its own Rules.
+type glove struct {
+	Material string
+	Size     string
+	Hand     hand
+}
+
+type gloveStyle struct {
+	Material string
+	Size     string
+}
+
+var gloveRules = sockpair.Rules[glove, gloveStyle]{
+	Key: func(g glove) gloveStyle {
+		return gloveStyle{g.Material, g.Size}
+	},
+	Complements: func(a, b glove) bool {
+		return a.Hand == anyHand || b.Hand == anyHand || a.Hand != b.Hand
+	},
+	Less: func(a, b glove) bool {
+		if a.Material != b.Material {
+			return a.Material < b.Material
+		}
+		if a.Size != b.Size {
+			return a.Size < b.Size
+		}
+		return a.Hand < b.Hand
+	},
+	Order: func(a, b glove) (glove, glove) {
+		if b.Hand == leftHand || a.Hand == rightHand {
+			return b, a
+		}
+		return a, b
+	},
+}
+
+// toGlove maps a Sock to a glove, so the shared test cases can be used to pair gloves.
+func toGlove(s sockpair.Sock) glove {
+	g := glove{Material: s.Color, Size: s.Pattern, Hand: anyHand}
+	switch s.Side {
+	case sockpair.Left:
+		g.Hand = leftHand
+	case sockpair.Right:
+		g.Hand = rightHand
+	}
+	return g
+}
+
+func toGloves(socks sockpair.Socks) []glove {
+	gloves := make([]glove, 0, len(socks))
+	for _, s := range socks {
+		gloves = append(gloves, toGlove(s))
+	}
+	return gloves
+}
+
+type gloveStrategy func(context.Context, []glove, sockpair.Rules[glove, gloveStyle], sockpair.PairingOptions) (sockpair.Result[glove], error)
+
+func TestPairingStrategies_gloves(t *testing.T) {
+	strategies := []struct {
+		name     string
+		strategy gloveStrategy
+	}{
+		{"PairRandom", sockpair.PairRandom[glove, gloveStyle]},
+		{"PairSequential", sockpair.PairSequential[glove, gloveStyle]},
+		{"PairSortFirst", sockpair.PairSortFirst[glove, gloveStyle]},
+		{"PairSurface", sockpair.PairSurface[glove, gloveStyle]},
+	}
+	for _, st := range strategies {
+		for _, tt := range sockpairtest.TestCases() {
+			t.Run(st.name+"/"+tt.Name, func(t *testing.T) {
+				opts := sockpair.PairingOptions{Rand: newRand(t)}
+				got, err := st.strategy(context.Background(), toGloves(tt.FreshSocks), gloveRules, opts)
+				if err != nil {
+					t.Fatalf("%s() error = %v", st.name, err)
+				}
+
+				wantPairs := make([][]glove, 0)
+				for _, pair := range tt.WantPairs {
+					wantPairs = append(wantPairs, toGloves(pair))
+				}
+				// sort the returned values, so they can be compared to our shared test values
+				sort.Slice(got.Pairs, func(i, j int) bool {
+					return gloveRules.Less(got.Pairs[i][0], got.Pairs[j][0])
+				})
+				sort.Slice(got.Orphans, func(i, j int) bool {
+					return gloveRules.Less(got.Orphans[i], got.Orphans[j])
+				})
+
+				if !reflect.DeepEqual(got.Pairs, wantPairs) {
+					t.Errorf("%s() pairs = %v, want %v", st.name, got.Pairs, wantPairs)
+				}
+				if !reflect.DeepEqual(got.Orphans, toGloves(tt.WantOrphans)) {
+					t.Errorf("%s() orphans = %v, want %v", st.name, got.Orphans, toGloves(tt.WantOrphans))
+				}
+			})
+		}
+	}
+}
+
+func TestPairSortFirst_withoutLess(t *testing.T) {
+	rules := gloveRules
+	rules.Less = nil
+	if _, err := sockpair.PairSortFirst(context.Background(), []glove{{Hand: leftHand}}, rules, sockpair.PairingOptions{}); err == nil {
+		t.Errorf("PairSortFirst() without Rules.Less did not return an error")
+	}
+}
+
+func BenchmarkPairSurface_gloves(b *testing.B) {
+	gloves := toGloves(sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"leather", "wool", "cotton", "nitrile", "fleece", "silk", "latex"},
+		[]string{"XS", "S", "M", "L", "XL"},
+		10,
+		false,
+	), newRand(b)))
+	for i := 0; i < b.N; i++ {
+		sockpair.PairSurface(context.Background(), gloves, gloveRules, sockpair.PairingOptions{})
+	}
+}
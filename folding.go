@@ -0,0 +1,205 @@
+package sock_pair_in_golang
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FoldingSimulation simulates several people pairing one basket together, as a household might,
+// each drawing socks from the shared basket and laying them out on one shared surface as
+// SurfacePairingStrategy does. Only one person can work at the surface at a time, so the others
+// wait their turn. Time is simulated with an EffortModel, so runs are repeatable.
+type FoldingSimulation struct {
+	// People is the number of people pairing, each run in its own goroutine. Zero means one person.
+	People int
+	// Matcher decides which socks pair. A nil Matcher uses ExactMatcher.
+	Matcher Matcher
+	// Effort is the time each step of pairing takes. The zero EffortModel uses DefaultEffortModel.
+	Effort EffortModel
+}
+
+// FolderReport is the work done by one person in a FoldingSimulation.
+type FolderReport struct {
+	// Draws is the number of socks the person drew from the basket.
+	Draws int
+	// Pairs is the number of pairs the person completed.
+	Pairs int
+	// Busy is the time the person spent drawing socks and working at the surface.
+	Busy time.Duration
+	// Idle is the time the person spent waiting for the surface, or for the others to finish
+	// once the basket was empty.
+	Idle time.Duration
+}
+
+// FoldingReport is the outcome of a FoldingSimulation.
+type FoldingReport struct {
+	Result PairingResult
+	// Folders is the work done by each person.
+	Folders []FolderReport
+	// Time is the time taken until the last person finished.
+	Time time.Duration
+	// SoloTime is the time one person would have taken to pair the basket alone.
+	SoloTime time.Duration
+}
+
+// Speedup returns how many times faster the people pairing together were than one person alone.
+func (r FoldingReport) Speedup() float64 {
+	if r.Time == 0 {
+		return 1
+	}
+	return float64(r.SoloTime) / float64(r.Time)
+}
+
+// Run simulates pairing the basket. It never modifies the basket.
+func (s FoldingSimulation) Run(basket Socks) FoldingReport {
+	report, _ := s.RunContext(context.Background(), basket)
+	return report
+}
+
+// RunContext simulates pairing the basket, stopping early if ctx is done. Socks that were still
+// in the basket or on the surface when it stopped are returned as Unprocessed, along with the
+// context's error.
+func (s FoldingSimulation) RunContext(ctx context.Context, basket Socks) (FoldingReport, error) {
+	people := s.People
+	if people < 1 {
+		people = 1
+	}
+
+	report, err := s.run(ctx, basket, people)
+	if err != nil || people == 1 {
+		report.SoloTime = report.Time
+		return report, err
+	}
+	solo, err := s.run(ctx, basket, 1)
+	report.SoloTime = solo.Time
+	return report, err
+}
+
+// foldingTable is the basket and surface shared by the people in a FoldingSimulation. People take
+// turns in the order of their simulated clocks, so the simulation runs the same way every time.
+type foldingTable struct {
+	mu      sync.Mutex
+	turn    *sync.Cond
+	effort  EffortModel
+	rules   Rules[Sock, Sock]
+	basket  Socks
+	surface *surface[Sock, Sock]
+	stats   PairingStats
+	pairs   SockPairs
+	// surfaceFree is when the person working at the surface will be done with it.
+	surfaceFree time.Duration
+	clocks      []time.Duration
+	finished    []bool
+	folders     []FolderReport
+}
+
+// next returns the unfinished person with the earliest clock, who takes the next turn.
+func (t *foldingTable) next() int {
+	person := -1
+	for i, clock := range t.clocks {
+		if !t.finished[i] && (person < 0 || clock < t.clocks[person]) {
+			person = i
+		}
+	}
+	return person
+}
+
+// step has person draw a Sock and take it to the surface, returning false once there is nothing
+// left for them to do.
+func (t *foldingTable) step(ctx context.Context, person int) bool {
+	if ctx.Err() != nil || len(t.basket) == 0 {
+		return false
+	}
+
+	sock := t.basket[0]
+	t.basket = t.basket[1:]
+	t.stats.Steps++
+	t.stats.Draws++
+	t.folders[person].Draws++
+	t.clocks[person] += t.effort.Draw
+	t.folders[person].Busy += t.effort.Draw
+
+	// wait for whoever is at the surface
+	if t.surfaceFree > t.clocks[person] {
+		t.folders[person].Idle += t.surfaceFree - t.clocks[person]
+		t.clocks[person] = t.surfaceFree
+	}
+
+	comparisons, placements, scans := t.stats.Comparisons, t.stats.Placements, t.stats.Scans
+	if matchingSock, ok := t.surface.take(sock); ok {
+		t.pairs = append(t.pairs, t.rules.pair(sock, matchingSock))
+		t.folders[person].Pairs++
+	} else {
+		t.surface.place(sock)
+	}
+	spent := t.effort.Estimate(PairingStats{
+		Comparisons: t.stats.Comparisons - comparisons,
+		Placements:  t.stats.Placements - placements,
+		Scans:       t.stats.Scans - scans,
+	})
+	t.clocks[person] += spent
+	t.folders[person].Busy += spent
+	t.surfaceFree = t.clocks[person]
+	return true
+}
+
+func (s FoldingSimulation) run(ctx context.Context, basket Socks, people int) (FoldingReport, error) {
+	effort := s.Effort
+	if effort == (EffortModel{}) {
+		effort = DefaultEffortModel
+	}
+	rules := MatcherRules(s.Matcher)
+	table := &foldingTable{
+		effort:   effort,
+		rules:    rules,
+		basket:   basket,
+		pairs:    make(SockPairs, 0),
+		clocks:   make([]time.Duration, people),
+		finished: make([]bool, people),
+		folders:  make([]FolderReport, people),
+	}
+	table.turn = sync.NewCond(&table.mu)
+	table.surface = newSurface(rules, &table.stats)
+
+	var wg sync.WaitGroup
+	for person := 0; person < people; person++ {
+		wg.Add(1)
+		go func(person int) {
+			defer wg.Done()
+			table.mu.Lock()
+			defer table.mu.Unlock()
+			for {
+				for table.next() != person {
+					table.turn.Wait()
+				}
+				if !table.step(ctx, person) {
+					table.finished[person] = true
+					table.turn.Broadcast()
+					return
+				}
+				table.turn.Broadcast()
+			}
+		}(person)
+	}
+	wg.Wait()
+
+	report := FoldingReport{Folders: table.folders}
+	for _, clock := range table.clocks {
+		if clock > report.Time {
+			report.Time = clock
+		}
+	}
+	for i := range report.Folders {
+		report.Folders[i].Idle += report.Time - table.clocks[i]
+	}
+
+	report.Result = PairingResult{Pairs: table.pairs, Orphans: make(Socks, 0), Stats: table.stats}
+	if err := ctx.Err(); err != nil {
+		// socks still on the surface may yet be matched by those left in the basket
+		report.Result.Unprocessed = append(table.surface.clear(), table.basket...)
+		return report, err
+	}
+	report.Result.Orphans = table.surface.clear()
+	return report, nil
+}
@@ -0,0 +1,96 @@
+package sock_pair_in_golang_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestFoldingSimulation_Run(t *testing.T) {
+	basket := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		2,
+		false,
+	), newRand(t))
+	sockpair.AssignIDs(basket, "sock")
+	alone := sockpair.SurfacePairingStrategy{}.PairSocks(basket)
+
+	for _, people := range []int{0, 1, 2, 3, 8} {
+		got := sockpair.FoldingSimulation{People: people}.Run(basket)
+		if err := sockpair.Audit(basket, got.Result); err != nil {
+			t.Fatalf("Audit(FoldingSimulation{People: %d}.Run()) error = %v", people, err)
+		}
+		// the people take turns at the surface, so together they do the same work as one person
+		if got.Result.Stats != alone.Stats || len(got.Result.Orphans) != len(alone.Orphans) {
+			t.Errorf("FoldingSimulation{People: %d}.Run() = %+v, want %+v", people, got.Result, alone)
+		}
+		if want := sockpair.DefaultEffortModel.Estimate(alone.Stats); got.SoloTime != want {
+			t.Errorf("FoldingSimulation{People: %d}.Run() solo time = %v, want %v", people, got.SoloTime, want)
+		}
+		if got.Time > got.SoloTime {
+			t.Errorf("FoldingSimulation{People: %d}.Run() time = %v, slower than %v alone", people, got.Time, got.SoloTime)
+		}
+
+		draws, pairs := 0, 0
+		for _, folder := range got.Folders {
+			draws += folder.Draws
+			pairs += folder.Pairs
+			if folder.Busy+folder.Idle != got.Time {
+				t.Errorf("FoldingSimulation{People: %d}.Run() folder %+v does not account for %v", people, folder, got.Time)
+			}
+		}
+		if draws != len(basket) || pairs != len(got.Result.Pairs) {
+			t.Errorf("FoldingSimulation{People: %d}.Run() folders drew %d socks and paired %d, want %d and %d", people, draws, pairs, len(basket), len(got.Result.Pairs))
+		}
+	}
+}
+
+func TestFoldingSimulation_Run_timing(t *testing.T) {
+	basket := sockpair.Socks{
+		{Color: "red", Side: sockpair.Left},
+		{Color: "blue", Side: sockpair.Left},
+		{Color: "red", Side: sockpair.Right},
+		{Color: "blue", Side: sockpair.Right},
+	}
+	effort := sockpair.EffortModel{Draw: 3 * time.Second, Comparison: time.Second, Placement: time.Second, Scan: time.Second}
+	got := sockpair.FoldingSimulation{People: 2, Effort: effort}.Run(basket)
+
+	// each person draws for 3s and spends 2s at the surface, either placing or pairing. The second
+	// person waits 2s for the surface at first, and the first waits 2s for the second to finish.
+	want := []sockpair.FolderReport{
+		{Draws: 2, Pairs: 1, Busy: 10 * time.Second, Idle: 2 * time.Second},
+		{Draws: 2, Pairs: 1, Busy: 10 * time.Second, Idle: 2 * time.Second},
+	}
+	if !reflect.DeepEqual(got.Folders, want) {
+		t.Errorf("FoldingSimulation.Run() folders = %+v, want %+v", got.Folders, want)
+	}
+	if got.Time != 12*time.Second || got.SoloTime != 20*time.Second {
+		t.Errorf("FoldingSimulation.Run() time = %v, solo time = %v, want 12s and 20s", got.Time, got.SoloTime)
+	}
+	if got, want := got.Speedup(), 20.0/12; got != want {
+		t.Errorf("FoldingReport.Speedup() = %v, want %v", got, want)
+	}
+}
+
+func TestFoldingSimulation_RunContext_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	basket := sockpair.GenerateSocks([]string{"red", "blue"}, []string{"plain"}, 2, false)
+
+	got, err := sockpair.FoldingSimulation{People: 3}.RunContext(ctx, basket)
+	if err != ctx.Err() {
+		t.Errorf("FoldingSimulation.RunContext() error = %v, want %v", err, ctx.Err())
+	}
+	unprocessed := append(sockpair.Socks{}, got.Result.Unprocessed...)
+	sort.Sort(unprocessed)
+	want := append(sockpair.Socks{}, basket...)
+	sort.Sort(want)
+	if len(got.Result.Pairs) != 0 || len(got.Result.Orphans) != 0 || !reflect.DeepEqual(unprocessed, want) {
+		t.Errorf("FoldingSimulation.RunContext() = %+v, want every sock unprocessed", got.Result)
+	}
+}
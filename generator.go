@@ -0,0 +1,119 @@
+package sock_pair_in_golang
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// BasketGenerator generates baskets that look like real laundry rather than a perfectly uniform
+// set of pairs: some colors and patterns are far more popular than others, the same pair is often
+// bought several times over, and some socks never make it out of the dryer.
+type BasketGenerator struct {
+	// Variety lists the attribute values each pair is drawn from.
+	Variety Variety
+	// Pairs is the number of pairs put in the wash. Negative values are treated as zero.
+	Pairs int
+	// LossProbability is the chance of each Sock being eaten by the dryer monster, leaving its
+	// partner an orphan.
+	LossProbability float64
+	// DuplicateProbability is the chance of each pair being another of a pair already in the
+	// basket, as with socks bought in multipacks.
+	DuplicateProbability float64
+	// Zipf skews the popularity of each attribute's values, drawing the value of rank k with
+	// probability proportional to 1/k^Zipf, so earlier values in Variety are more popular.
+	// Zero draws every value equally often.
+	Zipf float64
+	// Rand is the source of randomness, so a basket can be reproduced from its seed. A nil Rand
+	// uses the shared source from the math/rand package.
+	Rand *rand.Rand
+}
+
+// Generate returns a shuffled basket of the socks that survived the wash, along with the socks
+// that were lost.
+func (g BasketGenerator) Generate() (basket, lost Socks) {
+	if g.Pairs < 0 {
+		g.Pairs = 0
+	}
+	basket = make(Socks, 0, 2*g.Pairs)
+	lost = make(Socks, 0)
+	if len(g.Variety.Colors) == 0 || len(g.Variety.Patterns) == 0 {
+		return basket, lost
+	}
+
+	colors := newZipfPicker(len(g.Variety.Colors), g.Zipf)
+	patterns := newZipfPicker(len(g.Variety.Patterns), g.Zipf)
+	sizes := newZipfPicker(len(g.Variety.Sizes), g.Zipf)
+	owners := newZipfPicker(len(g.Variety.Owners), g.Zipf)
+	materials := newZipfPicker(len(g.Variety.Materials), g.Zipf)
+	brands := newZipfPicker(len(g.Variety.Brands), g.Zipf)
+	wears := newZipfPicker(len(g.Variety.Wears), g.Zipf)
+
+	pairs := make([]Sock, 0, g.Pairs)
+	for i := 0; i < g.Pairs; i++ {
+		var sock Sock
+		if len(pairs) > 0 && randomFloat(g.Rand) < g.DuplicateProbability {
+			sock = pairs[randomIndex(g.Rand, len(pairs))]
+		} else {
+			sock = Sock{
+				Color:    g.Variety.Colors[colors.pick(g.Rand)],
+				Pattern:  g.Variety.Patterns[patterns.pick(g.Rand)],
+				Size:     pickOrUnspecified(g.Variety.Sizes, sizes, g.Rand),
+				Owner:    pickOrUnspecified(g.Variety.Owners, owners, g.Rand),
+				Material: pickOrUnspecified(g.Variety.Materials, materials, g.Rand),
+				Brand:    pickOrUnspecified(g.Variety.Brands, brands, g.Rand),
+				Wear:     pickOrUnspecified(g.Variety.Wears, wears, g.Rand),
+			}
+		}
+		pairs = append(pairs, sock)
+
+		for _, side := range []Side{Left, Right} {
+			sock.Side = side
+			if randomFloat(g.Rand) < g.LossProbability {
+				lost = append(lost, sock)
+			} else {
+				basket = append(basket, sock)
+			}
+		}
+	}
+
+	return ShuffleSocksWithRand(basket, g.Rand), lost
+}
+
+// zipfPicker picks indexes in [0,n) following a Zipf distribution.
+type zipfPicker struct {
+	// cumulative holds the running total of the weight of each index.
+	cumulative []float64
+}
+
+func newZipfPicker(n int, exponent float64) zipfPicker {
+	cumulative := make([]float64, n)
+	total := 0.0
+	for k := range cumulative {
+		total += 1 / math.Pow(float64(k+1), exponent)
+		cumulative[k] = total
+	}
+	return zipfPicker{cumulative: cumulative}
+}
+
+func (z zipfPicker) pick(r *rand.Rand) int {
+	target := randomFloat(r) * z.cumulative[len(z.cumulative)-1]
+	return sort.SearchFloat64s(z.cumulative, target)
+}
+
+// pickOrUnspecified picks one of the values, or the zero value if there are none.
+func pickOrUnspecified[T any](values []T, z zipfPicker, r *rand.Rand) T {
+	if len(values) == 0 {
+		var unspecified T
+		return unspecified
+	}
+	return values[z.pick(r)]
+}
+
+// randomFloat returns a random number in [0,1) from r, or from the shared source if r is nil.
+func randomFloat(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
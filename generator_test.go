@@ -0,0 +1,147 @@
+package sock_pair_in_golang_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+var rainbow = sockpair.Variety{
+	Colors:   []string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+	Patterns: []string{"plain", "checkered", "herringbone", "plaid", "striped"},
+}
+
+func TestBasketGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name        string
+		generator   sockpair.BasketGenerator
+		wantBasket  int
+		wantLost    int
+		wantOrphans bool
+	}{
+		{"nothing lost", sockpair.BasketGenerator{Variety: rainbow, Pairs: 50}, 100, 0, false},
+		{"everything lost", sockpair.BasketGenerator{Variety: rainbow, Pairs: 50, LossProbability: 1}, 0, 100, false},
+		{"no pairs", sockpair.BasketGenerator{Variety: rainbow}, 0, 0, false},
+		{"no colors", sockpair.BasketGenerator{Variety: sockpair.Variety{Patterns: []string{"plain"}}, Pairs: 5}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.generator.Rand = newRand(t)
+			basket, lost := tt.generator.Generate()
+			if len(basket) != tt.wantBasket || len(lost) != tt.wantLost {
+				t.Fatalf("BasketGenerator.Generate() = %d socks, %d lost, want %d socks, %d lost", len(basket), len(lost), tt.wantBasket, tt.wantLost)
+			}
+			if got := (sockpair.SurfacePairingStrategy{}).PairSocks(basket); len(got.Orphans) != 0 {
+				t.Errorf("BasketGenerator.Generate() orphans = %v, want none", got.Orphans)
+			}
+		})
+	}
+}
+
+func TestBasketGenerator_Generate_loss(t *testing.T) {
+	g := sockpair.BasketGenerator{Variety: rainbow, Pairs: 5000, LossProbability: 0.1, Rand: newRand(t)}
+	basket, lost := g.Generate()
+	if len(basket)+len(lost) != 10000 {
+		t.Fatalf("BasketGenerator.Generate() = %d socks, %d lost, want 10000 in all", len(basket), len(lost))
+	}
+	if len(lost) < 800 || len(lost) > 1200 {
+		t.Errorf("BasketGenerator.Generate() lost %d socks, want around 1000", len(lost))
+	}
+}
+
+func TestBasketGenerator_Generate_negativePairs(t *testing.T) {
+	g := sockpair.BasketGenerator{Variety: rainbow, Pairs: -3, Rand: newRand(t)}
+	basket, lost := g.Generate()
+	if len(basket) != 0 || len(lost) != 0 {
+		t.Errorf("BasketGenerator.Generate() with %d pairs = %v, %v, want no socks", g.Pairs, basket, lost)
+	}
+}
+
+func TestBasketGenerator_Generate_reproducible(t *testing.T) {
+	g := sockpair.BasketGenerator{Variety: rainbow, Pairs: 30, LossProbability: 0.2, DuplicateProbability: 0.3, Zipf: 1}
+	g.Rand = rand.New(rand.NewSource(42))
+	first, firstLost := g.Generate()
+	g.Rand = rand.New(rand.NewSource(42))
+	second, secondLost := g.Generate()
+	if !reflect.DeepEqual(first, second) || !reflect.DeepEqual(firstLost, secondLost) {
+		t.Errorf("BasketGenerator.Generate() with the same seed = %v, %v, then %v, %v", first, firstLost, second, secondLost)
+	}
+}
+
+func TestBasketGenerator_Generate_duplicates(t *testing.T) {
+	g := sockpair.BasketGenerator{Variety: rainbow, Pairs: 20, DuplicateProbability: 1, Rand: newRand(t)}
+	basket, _ := g.Generate()
+	sort.Sort(basket)
+	if basket[0].Color != basket[len(basket)-1].Color || basket[0].Pattern != basket[len(basket)-1].Pattern {
+		t.Errorf("BasketGenerator.Generate() = %v, want every pair the same", basket)
+	}
+}
+
+func TestBasketGenerator_Generate_zipf(t *testing.T) {
+	counts := func(zipf float64) map[string]int {
+		g := sockpair.BasketGenerator{Variety: rainbow, Pairs: 7000, Zipf: zipf, Rand: newRand(t)}
+		basket, _ := g.Generate()
+		counts := make(map[string]int)
+		for _, s := range basket {
+			counts[s.Color]++
+		}
+		return counts
+	}
+
+	// red is seven times as popular as violet with an exponent of 1
+	skewed := counts(1)
+	if ratio := float64(skewed["red"]) / float64(skewed["violet"]); ratio < 5 || ratio > 9 {
+		t.Errorf("BasketGenerator.Generate() red to violet = %v, want around 7", ratio)
+	}
+
+	uniform := counts(0)
+	if ratio := float64(uniform["red"]) / float64(uniform["violet"]); ratio < 0.8 || ratio > 1.25 {
+		t.Errorf("BasketGenerator.Generate() uniform red to violet = %v, want around 1", ratio)
+	}
+}
+
+func TestBasketGenerator_Generate_variety(t *testing.T) {
+	g := sockpair.BasketGenerator{
+		Variety: sockpair.Variety{
+			Colors:   []string{"red"},
+			Patterns: []string{"plain"},
+			Owners:   []string{"sam", "alex"},
+		},
+		Pairs: 50,
+		Rand:  newRand(t),
+	}
+	basket, _ := g.Generate()
+	owners := make(map[string]bool)
+	for _, s := range basket {
+		owners[s.Owner] = true
+		if s.Size != "" || s.Wear != sockpair.UnknownWear {
+			t.Errorf("BasketGenerator.Generate() sock %v has attributes outside the variety", s)
+		}
+	}
+	if !owners["sam"] || !owners["alex"] || len(owners) != 2 {
+		t.Errorf("BasketGenerator.Generate() owners = %v, want sam and alex", owners)
+	}
+}
+
+func BenchmarkSockPairingStrategy_PairSocks_realistic(b *testing.B) {
+	g := sockpair.BasketGenerator{Variety: rainbow, Pairs: 175, LossProbability: 0.02, DuplicateProbability: 0.2, Zipf: 1, Rand: newRand(b)}
+	testSocks, _ := g.Generate()
+	strategies := []sockpair.SockPairingStrategy{
+		sockpair.SequentialPairingStrategy{},
+		sockpair.SortFirstPairingStrategy{},
+		sockpair.SurfacePairingStrategy{},
+		sockpair.BucketPairingStrategy{},
+		sockpair.MaximumMatchingPairingStrategy{},
+	}
+	for _, strategy := range strategies {
+		b.Run(fmt.Sprintf("%T", strategy), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				strategy.PairSocks(testSocks)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Package strategies names the pairing strategies the commands can be asked to use, so that every
+// command offers the same ones under the same names.
+package strategies
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+// byName are the strategies that can be chosen by name, given the seed for random draws.
+var byName = map[string]func(seed int64) sockpair.SockPairingStrategy{
+	"random": func(seed int64) sockpair.SockPairingStrategy {
+		return sockpair.RandomPairingStrategy{Rand: rand.New(rand.NewSource(seed))}
+	},
+	"sequential": func(int64) sockpair.SockPairingStrategy {
+		return sockpair.SequentialPairingStrategy{}
+	},
+	"sort-first": func(int64) sockpair.SockPairingStrategy {
+		return sockpair.SortFirstPairingStrategy{}
+	},
+	"surface": func(int64) sockpair.SockPairingStrategy {
+		return sockpair.SurfacePairingStrategy{}
+	},
+	"bounded-surface": func(int64) sockpair.SockPairingStrategy {
+		return sockpair.BoundedSurfacePairingStrategy{}
+	},
+	"bucket": func(int64) sockpair.SockPairingStrategy {
+		return sockpair.BucketPairingStrategy{}
+	},
+	"maximum-matching": func(int64) sockpair.SockPairingStrategy {
+		return sockpair.MaximumMatchingPairingStrategy{}
+	},
+	"parallel": func(int64) sockpair.SockPairingStrategy {
+		return sockpair.ParallelPairingStrategy{}
+	},
+}
+
+// New returns the strategy with the given name, seeding any random draws it makes with seed.
+// It returns false if there is no such strategy.
+func New(name string, seed int64) (sockpair.SockPairingStrategy, bool) {
+	newStrategy, ok := byName[name]
+	if !ok {
+		return nil, false
+	}
+	return newStrategy(seed), true
+}
+
+// Names returns the names of the strategies, sorted.
+func Names() []string {
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List returns the names of the strategies as a comma-separated list, for messages.
+func List() string {
+	return strings.Join(Names(), ", ")
+}
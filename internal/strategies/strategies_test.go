@@ -0,0 +1,14 @@
+package strategies
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	for _, name := range Names() {
+		if strategy, ok := New(name, 1); !ok || strategy == nil {
+			t.Errorf("New(%q) = %v, %v, want a strategy", name, strategy, ok)
+		}
+	}
+	if _, ok := New("folding", 1); ok {
+		t.Errorf("New(%q) found a strategy", "folding")
+	}
+}
@@ -0,0 +1,113 @@
+package sock_pair_in_golang
+
+import (
+	"context"
+	"fmt"
+)
+
+// Laundry simulates washing a sequence of loads. The socks left orphaned by each load are kept in
+// an orphan drawer, which is emptied into the next load so they can find partners that were
+// still in the wash. The zero Laundry is ready to use.
+type Laundry struct {
+	// Strategy pairs each load. A nil Strategy uses SurfacePairingStrategy.
+	Strategy SockPairingStrategy
+	// Drawer holds the socks still waiting for a partner.
+	Drawer Socks
+	// Loads holds the stats of every load washed so far, oldest first.
+	Loads []LoadStats
+	// History, if set, records what became of every Sock in each load.
+	History *History
+}
+
+// LoadStats describe the outcome of washing a single load.
+type LoadStats struct {
+	// Socks is the number of socks in the load, not counting those taken from the drawer.
+	Socks int
+	// FromDrawer is the number of socks taken from the drawer to be paired with the load.
+	FromDrawer int
+	Pairs      int
+	// Reunited is the number of pairs made with a Sock taken from the drawer.
+	Reunited int
+	// Orphans is the number of socks put in the drawer once the load was paired.
+	Orphans int
+	Stats   PairingStats
+}
+
+// LaundryStats are the totals across every load washed.
+type LaundryStats struct {
+	Loads int
+	// Socks is the number of socks washed, counting each Sock once however many loads it waited in the drawer.
+	Socks    int
+	Pairs    int
+	Reunited int
+	// Orphans is the number of socks now in the drawer.
+	Orphans int
+	Stats   PairingStats
+}
+
+func (l *Laundry) strategy() SockPairingStrategy {
+	if l.Strategy == nil {
+		return SurfacePairingStrategy{}
+	}
+	return l.Strategy
+}
+
+// Wash pairs a load along with the socks in the drawer, then puts the socks left orphaned in the drawer.
+func (l *Laundry) Wash(load Socks) PairingResult {
+	res, _ := l.WashContext(context.Background(), load)
+	return res
+}
+
+// WashContext is Wash, giving up once ctx is done. Socks that were not processed are put back in
+// the drawer along with the orphans.
+func (l *Laundry) WashContext(ctx context.Context, load Socks) (PairingResult, error) {
+	basket := append(append(Socks{}, l.Drawer...), load...)
+	res, err := PairContext(ctx, l.strategy(), basket)
+
+	// count the pairs made with socks from the drawer, which may be any of several identical socks
+	fromDrawer := make(map[Sock]int)
+	for _, s := range l.Drawer {
+		fromDrawer[s]++
+	}
+	reunited := 0
+	for _, pair := range res.Pairs {
+		found := false
+		for _, s := range pair {
+			if fromDrawer[s] > 0 {
+				fromDrawer[s]--
+				found = true
+			}
+		}
+		if found {
+			reunited++
+		}
+	}
+
+	stats := LoadStats{
+		Socks:      len(load),
+		FromDrawer: len(l.Drawer),
+		Pairs:      len(res.Pairs),
+		Reunited:   reunited,
+		Orphans:    len(res.Orphans) + len(res.Unprocessed),
+		Stats:      res.Stats,
+	}
+	l.Loads = append(l.Loads, stats)
+	l.Drawer = append(append(Socks{}, res.Orphans...), res.Unprocessed...)
+	if l.History != nil {
+		l.History.Record(fmt.Sprintf("load %d", len(l.Loads)), res)
+	}
+
+	return res, err
+}
+
+// Totals returns the totals across every load washed so far.
+func (l *Laundry) Totals() LaundryStats {
+	totals := LaundryStats{Loads: len(l.Loads), Orphans: len(l.Drawer)}
+	for _, load := range l.Loads {
+		totals.Socks += load.Socks
+		totals.Pairs += load.Pairs
+		totals.Reunited += load.Reunited
+		totals.Stats = totals.Stats.add(load.Stats)
+	}
+	return totals
+}
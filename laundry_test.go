@@ -0,0 +1,109 @@
+package sock_pair_in_golang_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestLaundry_Wash(t *testing.T) {
+	redLeft := sockpair.Sock{ID: "1", Color: "red", Pattern: "plain", Side: sockpair.Left}
+	redRight := sockpair.Sock{ID: "2", Color: "red", Pattern: "plain", Side: sockpair.Right}
+	blueLeft := sockpair.Sock{ID: "3", Color: "blue", Pattern: "plain", Side: sockpair.Left}
+	blueRight := sockpair.Sock{ID: "4", Color: "blue", Pattern: "plain", Side: sockpair.Right}
+	greenLeft := sockpair.Sock{ID: "5", Color: "green", Pattern: "plain", Side: sockpair.Left}
+	greenRight := sockpair.Sock{ID: "6", Color: "green", Pattern: "plain", Side: sockpair.Right}
+	pinkLeft := sockpair.Sock{ID: "7", Color: "pink", Pattern: "plain", Side: sockpair.Left}
+
+	loads := []sockpair.Socks{
+		{redLeft, blueLeft, greenLeft, greenRight},
+		{blueRight, pinkLeft},
+		{redRight},
+	}
+	wantLoads := []sockpair.LoadStats{
+		{Socks: 4, FromDrawer: 0, Pairs: 1, Reunited: 0, Orphans: 2},
+		{Socks: 2, FromDrawer: 2, Pairs: 1, Reunited: 1, Orphans: 2},
+		{Socks: 1, FromDrawer: 2, Pairs: 1, Reunited: 1, Orphans: 1},
+	}
+
+	strategies := []sockpair.SockPairingStrategy{
+		nil,
+		sockpair.RandomPairingStrategy{Rand: newRand(t)},
+		sockpair.SequentialPairingStrategy{},
+		sockpair.SortFirstPairingStrategy{},
+		sockpair.SurfacePairingStrategy{},
+		sockpair.MaximumMatchingPairingStrategy{},
+	}
+	for _, strategy := range strategies {
+		t.Run(fmt.Sprintf("%T", strategy), func(t *testing.T) {
+			laundry := sockpair.Laundry{Strategy: strategy, History: &sockpair.History{}}
+			for _, load := range loads {
+				laundry.Wash(load)
+			}
+
+			for i, got := range laundry.Loads {
+				got.Stats = sockpair.PairingStats{}
+				if got != wantLoads[i] {
+					t.Errorf("Laundry.Loads[%d] = %+v, want %+v", i, got, wantLoads[i])
+				}
+			}
+			if want := (sockpair.Socks{pinkLeft}); !reflect.DeepEqual(laundry.Drawer, want) {
+				t.Errorf("Laundry.Drawer = %v, want %v", laundry.Drawer, want)
+			}
+
+			totals := laundry.Totals()
+			totals.Stats = sockpair.PairingStats{}
+			if want := (sockpair.LaundryStats{Loads: 3, Socks: 7, Pairs: 3, Reunited: 2, Orphans: 1}); totals != want {
+				t.Errorf("Laundry.Totals() = %+v, want %+v", totals, want)
+			}
+
+			// the red left sock waits in the drawer for two loads before its partner turns up
+			want := []sockpair.Event{
+				{Load: "load 1", Kind: sockpair.Orphaned, Sock: redLeft},
+				{Load: "load 2", Kind: sockpair.Orphaned, Sock: redLeft},
+				{Load: "load 3", Kind: sockpair.Paired, Sock: redLeft, Partner: redRight},
+			}
+			if got := laundry.History.Trace("1"); !reflect.DeepEqual(got, want) {
+				t.Errorf("History.Trace() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLaundry_Totals_stats(t *testing.T) {
+	laundry := sockpair.Laundry{}
+	first := laundry.Wash(sockpair.GenerateSocks([]string{"red", "blue", "green"}, []string{"plain"}, 1, false))
+	second := laundry.Wash(sockpair.GenerateSocks([]string{"red"}, []string{"plain"}, 1, false))
+
+	want := sockpair.PairingStats{
+		Comparisons: first.Stats.Comparisons + second.Stats.Comparisons,
+		Draws:       first.Stats.Draws + second.Stats.Draws,
+		Removals:    first.Stats.Removals + second.Stats.Removals,
+		SurfacePeak: first.Stats.SurfacePeak,
+		Placements:  first.Stats.Placements + second.Stats.Placements,
+		Scans:       first.Stats.Scans + second.Stats.Scans,
+		Steps:       first.Stats.Steps + second.Stats.Steps,
+	}
+	if got := laundry.Totals().Stats; got != want {
+		t.Errorf("Laundry.Totals().Stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestLaundry_WashContext_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	laundry := sockpair.Laundry{Drawer: sockpair.Socks{{Color: "pink", Pattern: "plain", Side: sockpair.Left}}}
+	load := sockpair.GenerateSocks([]string{"red", "blue"}, []string{"plain"}, 1, false)
+	if _, err := laundry.WashContext(ctx, load); err != ctx.Err() {
+		t.Errorf("Laundry.WashContext() error = %v, want %v", err, ctx.Err())
+	}
+
+	// nothing was paired, so every sock waits in the drawer
+	if len(laundry.Drawer) != len(load)+1 {
+		t.Errorf("Laundry.Drawer = %v, want the %d socks of the load and the drawer", laundry.Drawer, len(load)+1)
+	}
+}
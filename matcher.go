@@ -0,0 +1,210 @@
+package sock_pair_in_golang
+
+import "strings"
+
+// Matcher decides which socks make a pair.
+//
+// MatchKey must return the same key for any two socks that IsMatchingPair reports as a pair,
+// so strategies can set aside socks with different keys without comparing them. A key is itself
+// a Sock, typically the given Sock with the attributes the Matcher ignores cleared.
+type Matcher interface {
+	MatchKey(s Sock) Sock
+	IsMatchingPair(a, b Sock) bool
+}
+
+// SortingMatcher is a Matcher that orders socks itself, for when grouping them by match key does
+// not bring those which pair next to each other.
+type SortingMatcher interface {
+	Matcher
+	Less(a, b Sock) bool
+}
+
+// sidedMatcher is implemented by Matchers that can say which side each Sock pairs by. sides
+// returns nil if socks sharing a match key do not pair exactly when their sides complement one
+// another, such as when their colors must also be close.
+type sidedMatcher interface {
+	sides() func(s Sock) Side
+}
+
+// sockSide returns the side a Sock is made for.
+func sockSide(s Sock) Side {
+	return s.Side
+}
+
+// eitherSide treats every Sock as fitting either foot, for matchers that ignore sides.
+func eitherSide(Sock) Side {
+	return Either
+}
+
+// MatcherRules returns the Rules for pairing socks with the given Matcher.
+// A nil Matcher pairs socks using ExactMatcher.
+func MatcherRules(m Matcher) Rules[Sock, Sock] {
+	if m == nil {
+		m = ExactMatcher{}
+	}
+
+	less := func(a, b Sock) bool {
+		// group socks by key, so those which pair sort next to each other
+		if keyA, keyB := m.MatchKey(a), m.MatchKey(b); keyA != keyB {
+			return lessSock(keyA, keyB)
+		}
+		return lessSock(a, b)
+	}
+	if sm, ok := m.(SortingMatcher); ok {
+		less = sm.Less
+	}
+
+	rules := Rules[Sock, Sock]{
+		Key:         m.MatchKey,
+		Complements: m.IsMatchingPair,
+		Less:        less,
+		Order:       orderSockPair,
+	}
+	if sm, ok := m.(sidedMatcher); ok {
+		rules.Side = sm.sides()
+	}
+	return rules
+}
+
+// ExactMatcher pairs socks of the same color, pattern, size, owner, material and brand made for
+// opposite feet, as Sock.IsMatchingPair does.
+type ExactMatcher struct{}
+
+func (m ExactMatcher) MatchKey(s Sock) Sock {
+	return AttributeMatcher{Attributes: identityAttributes}.MatchKey(s)
+}
+
+func (m ExactMatcher) IsMatchingPair(a, b Sock) bool {
+	return a.IsMatchingPair(b)
+}
+
+func (m ExactMatcher) sides() func(s Sock) Side {
+	return sockSide
+}
+
+// SockAttribute identifies an attribute of a Sock that an AttributeMatcher compares.
+type SockAttribute uint
+
+const (
+	ColorAttribute SockAttribute = 1 << iota
+	PatternAttribute
+	// SideAttribute requires a pair to be made for opposite feet, as Sock.IsMatchingPair does.
+	SideAttribute
+	SizeAttribute
+	OwnerAttribute
+	MaterialAttribute
+	BrandAttribute
+	WearAttribute
+)
+
+// identityAttributes are the attributes Sock.IsMatchingPair compares, besides the side.
+const identityAttributes = ColorAttribute | PatternAttribute | SizeAttribute | OwnerAttribute | MaterialAttribute | BrandAttribute
+
+// AttributeMatcher pairs socks that agree on a subset of their attributes, ignoring the rest.
+type AttributeMatcher struct {
+	Attributes SockAttribute
+}
+
+func (m AttributeMatcher) compares(attribute SockAttribute) bool {
+	return m.Attributes&attribute != 0
+}
+
+func (m AttributeMatcher) MatchKey(s Sock) Sock {
+	key := Sock{}
+	if m.compares(ColorAttribute) {
+		key.Color = s.Color
+	}
+	if m.compares(PatternAttribute) {
+		key.Pattern = s.Pattern
+	}
+	if m.compares(SizeAttribute) {
+		key.Size = s.Size
+	}
+	if m.compares(OwnerAttribute) {
+		key.Owner = s.Owner
+	}
+	if m.compares(MaterialAttribute) {
+		key.Material = s.Material
+	}
+	if m.compares(BrandAttribute) {
+		key.Brand = s.Brand
+	}
+	if m.compares(WearAttribute) {
+		key.Wear = s.Wear
+	}
+	return key
+}
+
+func (m AttributeMatcher) IsMatchingPair(a, b Sock) bool {
+	if m.MatchKey(a) != m.MatchKey(b) {
+		return false
+	}
+	return !m.compares(SideAttribute) || a.Side.complements(b.Side)
+}
+
+func (m AttributeMatcher) sides() func(s Sock) Side {
+	if m.compares(SideAttribute) {
+		return sockSide
+	}
+	return eitherSide
+}
+
+// SideAgnosticMatcher pairs any two socks that Sock.IsMatchingPair would pair if they were made
+// for opposite feet, whatever their sides.
+type SideAgnosticMatcher struct{}
+
+func (m SideAgnosticMatcher) MatchKey(s Sock) Sock {
+	return AttributeMatcher{Attributes: identityAttributes}.MatchKey(s)
+}
+
+func (m SideAgnosticMatcher) IsMatchingPair(a, b Sock) bool {
+	return AttributeMatcher{Attributes: identityAttributes}.IsMatchingPair(a, b)
+}
+
+func (m SideAgnosticMatcher) sides() func(s Sock) Side {
+	return eitherSide
+}
+
+// NormalizingMatcher compares colors, patterns, sizes, owners, materials and brands ignoring case
+// and surrounding or repeated whitespace, so "Navy  Blue" pairs with "navy blue", then defers to Matcher.
+// A nil Matcher defers to ExactMatcher.
+type NormalizingMatcher struct {
+	Matcher Matcher
+}
+
+func (m NormalizingMatcher) matcher() Matcher {
+	if m.Matcher == nil {
+		return ExactMatcher{}
+	}
+	return m.Matcher
+}
+
+func (m NormalizingMatcher) MatchKey(s Sock) Sock {
+	return m.matcher().MatchKey(normalizeSock(s))
+}
+
+func (m NormalizingMatcher) IsMatchingPair(a, b Sock) bool {
+	return m.matcher().IsMatchingPair(normalizeSock(a), normalizeSock(b))
+}
+
+func (m NormalizingMatcher) sides() func(s Sock) Side {
+	if sm, ok := m.matcher().(sidedMatcher); ok {
+		return sm.sides()
+	}
+	return nil
+}
+
+// normalizeSock returns the Sock with its text attributes lower cased and their whitespace collapsed.
+func normalizeSock(s Sock) Sock {
+	s.Color = normalizeText(s.Color)
+	s.Pattern = normalizeText(s.Pattern)
+	s.Size = normalizeText(s.Size)
+	s.Owner = normalizeText(s.Owner)
+	s.Material = normalizeText(s.Material)
+	s.Brand = normalizeText(s.Brand)
+	return s
+}
+
+func normalizeText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,120 @@
+package sock_pair_in_golang_test
+
+import (
+	"fmt"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestMatchers(t *testing.T) {
+	redLeft := sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Left}
+	redRight := sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Right}
+	redStripedRight := sockpair.Sock{Color: "red", Pattern: "striped", Side: sockpair.Right}
+	blueRight := sockpair.Sock{Color: "blue", Pattern: "plain", Side: sockpair.Right}
+	shoutyRedRight := sockpair.Sock{Color: " RED ", Pattern: "Plain", Side: sockpair.Right}
+	childRedRight := sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Right, Size: "child"}
+	wornRedRight := sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Right, Wear: sockpair.WornOut}
+	samsRedLeft := sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Left, Owner: "Sam"}
+	samsBlueRight := sockpair.Sock{Color: "blue", Pattern: "plain", Side: sockpair.Right, Owner: "sam"}
+
+	tests := []struct {
+		name    string
+		matcher sockpair.Matcher
+		a, b    sockpair.Sock
+		want    bool
+	}{
+		{"exact, left and right", sockpair.ExactMatcher{}, redLeft, redRight, true},
+		{"exact, two rights", sockpair.ExactMatcher{}, redRight, redRight, false},
+		{"exact, different patterns", sockpair.ExactMatcher{}, redLeft, redStripedRight, false},
+		{"exact, different case", sockpair.ExactMatcher{}, redLeft, shoutyRedRight, false},
+		{"side agnostic, two rights", sockpair.SideAgnosticMatcher{}, redRight, redRight, true},
+		{"side agnostic, different colors", sockpair.SideAgnosticMatcher{}, redRight, blueRight, false},
+		{"exact, different sizes", sockpair.ExactMatcher{}, redLeft, childRedRight, false},
+		{"side agnostic, different sizes", sockpair.SideAgnosticMatcher{}, redRight, childRedRight, false},
+		{"exact, different wear", sockpair.ExactMatcher{}, redLeft, wornRedRight, true},
+		{
+			"color, side and wear, different wear",
+			sockpair.AttributeMatcher{Attributes: sockpair.ColorAttribute | sockpair.SideAttribute | sockpair.WearAttribute},
+			redLeft,
+			wornRedRight,
+			false,
+		},
+		{
+			"owner and side, different colors",
+			sockpair.NormalizingMatcher{Matcher: sockpair.AttributeMatcher{Attributes: sockpair.OwnerAttribute | sockpair.SideAttribute}},
+			samsRedLeft,
+			samsBlueRight,
+			true,
+		},
+		{
+			"color and side, different patterns",
+			sockpair.AttributeMatcher{Attributes: sockpair.ColorAttribute | sockpair.SideAttribute},
+			redLeft,
+			redStripedRight,
+			true,
+		},
+		{
+			"color and side, two rights",
+			sockpair.AttributeMatcher{Attributes: sockpair.ColorAttribute | sockpair.SideAttribute},
+			redRight,
+			redStripedRight,
+			false,
+		},
+		{
+			"pattern only, different colors",
+			sockpair.AttributeMatcher{Attributes: sockpair.PatternAttribute},
+			redRight,
+			blueRight,
+			true,
+		},
+		{"normalizing, different case", sockpair.NormalizingMatcher{}, redLeft, shoutyRedRight, true},
+		{"normalizing, two rights", sockpair.NormalizingMatcher{}, redRight, shoutyRedRight, false},
+		{
+			"normalizing side agnostic, two rights",
+			sockpair.NormalizingMatcher{Matcher: sockpair.SideAgnosticMatcher{}},
+			redRight,
+			shoutyRedRight,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.IsMatchingPair(tt.a, tt.b); got != tt.want {
+				t.Errorf("%T.IsMatchingPair(%v, %v) = %v, want %v", tt.matcher, tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.matcher.IsMatchingPair(tt.b, tt.a); got != tt.want {
+				t.Errorf("%T.IsMatchingPair(%v, %v) = %v, want %v", tt.matcher, tt.b, tt.a, got, tt.want)
+			}
+			// socks that pair must share a match key
+			if tt.want && tt.matcher.MatchKey(tt.a) != tt.matcher.MatchKey(tt.b) {
+				t.Errorf("%T.MatchKey() differs for matching socks %v and %v", tt.matcher, tt.a, tt.b)
+			}
+		})
+	}
+}
+
+func TestSockPairingStrategy_PairSocks_matcher(t *testing.T) {
+	// two right socks and a pair differing only in case
+	basket := sockpair.Socks{
+		{Color: "red", Pattern: "plain", Side: sockpair.Right},
+		{Color: "Navy", Pattern: "plain", Side: sockpair.Left},
+		{Color: "red", Pattern: "plain", Side: sockpair.Right},
+		{Color: "navy ", Pattern: "Plain", Side: sockpair.Right},
+	}
+	matcher := sockpair.NormalizingMatcher{Matcher: sockpair.SideAgnosticMatcher{}}
+	strategies := []sockpair.SockPairingStrategy{
+		sockpair.RandomPairingStrategy{Rand: newRand(t), Matcher: matcher},
+		sockpair.SequentialPairingStrategy{Matcher: matcher},
+		sockpair.SortFirstPairingStrategy{Matcher: matcher},
+		sockpair.SurfacePairingStrategy{Matcher: matcher},
+	}
+	for _, strategy := range strategies {
+		t.Run(fmt.Sprintf("%T", strategy), func(t *testing.T) {
+			got := strategy.PairSocks(basket)
+			if len(got.Pairs) != 2 || len(got.Orphans) != 0 {
+				t.Errorf("%T.PairSocks() = %v, %v, want 2 pairs and no orphans", strategy, got.Pairs, got.Orphans)
+			}
+		})
+	}
+}
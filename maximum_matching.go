@@ -0,0 +1,120 @@
+package sock_pair_in_golang
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// costScale is the number of steps the costs of the pairs in a group are spread across, from the
+// cheapest to the dearest, so that PairMaximumMatching can compare them as integers.
+const costScale = 1e9
+
+// PairMaximumMatching pairs items by laying the whole basket out at once, working out which
+// items could pair with which, and choosing the pairs that leave the fewest items orphaned.
+// Unlike the greedy strategies, it never settles for a pair that strands an item which could
+// otherwise have been paired. When rules has a Cost, the cheapest of those choices is made.
+// It never modifies the basket.
+func PairMaximumMatching[T any, K comparable](ctx context.Context, items []T, rules Rules[T, K], opts PairingOptions) (Result[T], error) {
+	pairedItems := make([][]T, 0)
+	orphanedItems := make([]T, 0)
+	stats := PairingStats{}
+
+	// every item is laid out at once, grouped by match key as items with different keys never pair
+	stats.Draws = len(items)
+	stats.Placements = len(items)
+	stats.SurfacePeak = len(items)
+	keys := make([]K, 0)
+	groups := make(map[K][]T)
+	for _, item := range items {
+		key := rules.Key(item)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	for i, key := range keys {
+		stats.Steps++
+		group := groups[key]
+		mate, err := matchGroup(ctx, group, rules, &stats)
+		if err != nil {
+			unprocessedItems := make([]T, 0)
+			for _, key := range keys[i:] {
+				unprocessedItems = append(unprocessedItems, groups[key]...)
+			}
+			return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Unprocessed: unprocessedItems, Stats: stats}, err
+		}
+
+		for v, w := range mate {
+			if w == -1 {
+				orphanedItems = append(orphanedItems, group[v])
+			} else if v < w {
+				pairedItems = append(pairedItems, rules.pair(group[v], group[w]))
+			}
+		}
+	}
+
+	return Result[T]{Pairs: pairedItems, Orphans: orphanedItems, Stats: stats}, nil
+}
+
+// matchGroup finds a maximum matching, of least cost, between items sharing a match key. It returns
+// an error if rules has a Cost which is not a finite number.
+func matchGroup[T any, K comparable](ctx context.Context, group []T, rules Rules[T, K], stats *PairingStats) ([]int, error) {
+	edges := make([]weightedEdge, 0)
+	costs := make([]float64, 0)
+	minCost, maxCost := math.Inf(1), math.Inf(-1)
+	for v := range group {
+		for w := v + 1; w < len(group); w++ {
+			stats.Comparisons++
+			if !rules.Complements(group[v], group[w]) {
+				continue
+			}
+
+			cost := 0.0
+			if rules.Cost != nil {
+				cost = rules.Cost(group[v], group[w])
+				if math.IsNaN(cost) || math.IsInf(cost, 0) {
+					return nil, fmt.Errorf("cost of pairing %v and %v is %v, want a finite number", group[v], group[w], cost)
+				}
+			}
+			edges = append(edges, weightedEdge{i: v, j: w})
+			costs = append(costs, cost)
+			minCost, maxCost = math.Min(minCost, cost), math.Max(maxCost, cost)
+		}
+	}
+
+	// the cheapest pairs weigh the most, and every pair weighs something; the costs are scaled by
+	// their spread rather than converted as they are, so no weight can overflow
+	spread := maxCost/2 - minCost/2
+	for k, cost := range costs {
+		edges[k].weight = 1
+		if spread > 0 {
+			edges[k].weight += int64(math.Round((maxCost/2 - cost/2) / spread * costScale))
+		}
+	}
+	return maxWeightMatching(ctx, len(group), edges)
+}
+
+// MaximumMatchingPairingStrategy is the process of laying every Sock in the basket out at once,
+// working out which socks could pair with which, and choosing the pairs that leave the fewest
+// orphans rather than settling for the first match found. It never modifies the basket.
+type MaximumMatchingPairingStrategy struct {
+	// Matcher decides which socks pair. A nil Matcher uses ExactMatcher.
+	Matcher Matcher
+	// Cost, if set, returns how poor a pair two socks make, as a finite number. Of the choices
+	// that leave the fewest orphans, the one with the lowest total Cost is made.
+	Cost func(a, b Sock) float64
+}
+
+func (s MaximumMatchingPairingStrategy) PairSocks(freshSocks Socks) PairingResult {
+	res, _ := s.PairSocksContext(context.Background(), freshSocks)
+	return res
+}
+
+func (s MaximumMatchingPairingStrategy) PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error) {
+	rules := MatcherRules(s.Matcher)
+	rules.Cost = s.Cost
+	res, err := PairMaximumMatching(ctx, freshSocks, rules, PairingOptions{})
+	return newPairingResult(res), err
+}
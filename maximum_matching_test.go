@@ -0,0 +1,244 @@
+package sock_pair_in_golang_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/sockpairtest"
+)
+
+// nearColorMatcher pairs socks of the same pattern whose colors are next to each other on a
+// scale, so red pairs with crimson and crimson with maroon, but red never pairs with maroon.
+type nearColorMatcher struct {
+	scale []string
+}
+
+func (m nearColorMatcher) position(color string) int {
+	for i, c := range m.scale {
+		if c == color {
+			return i
+		}
+	}
+	return -10
+}
+
+func (m nearColorMatcher) MatchKey(s sockpair.Sock) sockpair.Sock {
+	return sockpair.Sock{Pattern: s.Pattern}
+}
+
+func (m nearColorMatcher) IsMatchingPair(a, b sockpair.Sock) bool {
+	distance := m.position(a.Color) - m.position(b.Color)
+	return a.Pattern == b.Pattern && distance >= -1 && distance <= 1
+}
+
+func TestMaximumMatchingPairingStrategy_PairSocks(t *testing.T) {
+	sockpairtest.TestStrategy(t, sockpair.MaximumMatchingPairingStrategy{})
+}
+
+func TestMaximumMatchingPairingStrategy_PairSocks_nearColors(t *testing.T) {
+	matcher := nearColorMatcher{scale: []string{"red", "crimson", "maroon", "brown"}}
+	basket := sockpair.Socks{
+		{Color: "crimson", Pattern: "plain"},
+		{Color: "maroon", Pattern: "plain"},
+		{Color: "red", Pattern: "plain"},
+		{Color: "brown", Pattern: "plain"},
+	}
+
+	// pairing crimson with maroon strands both red and brown
+	if got := (sockpair.SequentialPairingStrategy{Matcher: matcher}).PairSocks(basket); len(got.Orphans) != 2 {
+		t.Fatalf("SequentialPairingStrategy.PairSocks() orphans = %v, want 2 orphans", got.Orphans)
+	}
+
+	got := sockpair.MaximumMatchingPairingStrategy{Matcher: matcher}.PairSocks(basket)
+	if len(got.Pairs) != 2 || len(got.Orphans) != 0 {
+		t.Errorf("MaximumMatchingPairingStrategy.PairSocks() = %v, %v, want 2 pairs and no orphans", got.Pairs, got.Orphans)
+	}
+}
+
+func TestMaximumMatchingPairingStrategy_PairSocks_cost(t *testing.T) {
+	// pair socks of any color, preferring the same color
+	strategy := sockpair.MaximumMatchingPairingStrategy{
+		Matcher: sockpair.AttributeMatcher{Attributes: sockpair.PatternAttribute | sockpair.SideAttribute},
+		Cost: func(a, b sockpair.Sock) float64 {
+			if a.Color == b.Color {
+				return 0
+			}
+			return 1
+		},
+	}
+	basket := sockpair.Socks{
+		{Color: "navy", Pattern: "plain", Side: sockpair.Left},
+		{Color: "blue", Pattern: "plain", Side: sockpair.Right},
+		{Color: "navy", Pattern: "plain", Side: sockpair.Right},
+		{Color: "blue", Pattern: "plain", Side: sockpair.Left},
+		{Color: "black", Pattern: "plain", Side: sockpair.Left},
+	}
+
+	got := strategy.PairSocks(basket)
+	if len(got.Pairs) != 2 || len(got.Orphans) != 1 {
+		t.Fatalf("MaximumMatchingPairingStrategy.PairSocks() = %v, %v, want 2 pairs and 1 orphan", got.Pairs, got.Orphans)
+	}
+	for _, pair := range got.Pairs {
+		if pair[0].Color != pair[1].Color {
+			t.Errorf("MaximumMatchingPairingStrategy.PairSocks() paired %v, want socks of the same color", pair)
+		}
+		if pair[0].Side != sockpair.Left || pair[1].Side != sockpair.Right {
+			t.Errorf("MaximumMatchingPairingStrategy.PairSocks() paired %v, want left then right", pair)
+		}
+	}
+}
+
+func TestMaximumMatchingPairingStrategy_PairSocks_widelySpreadCosts(t *testing.T) {
+	basket := sockpair.Socks{
+		{Color: "navy", Pattern: "plain", Side: sockpair.Left},
+		{Color: "blue", Pattern: "plain", Side: sockpair.Right},
+		{Color: "navy", Pattern: "plain", Side: sockpair.Right},
+		{Color: "blue", Pattern: "plain", Side: sockpair.Left},
+		{Color: "black", Pattern: "plain", Side: sockpair.Left},
+	}
+	for _, spread := range []float64{1e-12, 1e13, math.MaxFloat64} {
+		strategy := sockpair.MaximumMatchingPairingStrategy{
+			Matcher: sockpair.AttributeMatcher{Attributes: sockpair.PatternAttribute | sockpair.SideAttribute},
+			Cost: func(a, b sockpair.Sock) float64 {
+				switch {
+				case a.Color == b.Color:
+					return 0
+				case a.Color == "black" || b.Color == "black":
+					return spread
+				}
+				return 0.9 * spread
+			},
+		}
+		got := strategy.PairSocks(basket)
+		if len(got.Pairs) != 2 || len(got.Orphans) != 1 || got.Orphans[0].Color != "black" {
+			t.Fatalf("MaximumMatchingPairingStrategy.PairSocks() with costs up to %v = %v, %v, want 2 pairs and the black sock orphaned",
+				spread, got.Pairs, got.Orphans)
+		}
+		for _, pair := range got.Pairs {
+			if pair[0].Color != pair[1].Color {
+				t.Errorf("MaximumMatchingPairingStrategy.PairSocks() with costs up to %v paired %v, want socks of the same color", spread, pair)
+			}
+		}
+	}
+}
+
+func TestMaximumMatchingPairingStrategy_PairSocksContext_nonFiniteCost(t *testing.T) {
+	basket := sockpair.Socks{
+		{Color: "navy", Pattern: "plain", Side: sockpair.Left},
+		{Color: "navy", Pattern: "plain", Side: sockpair.Right},
+	}
+	for _, cost := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		strategy := sockpair.MaximumMatchingPairingStrategy{
+			Cost: func(a, b sockpair.Sock) float64 { return cost },
+		}
+		got, err := strategy.PairSocksContext(context.Background(), basket)
+		if err == nil {
+			t.Errorf("MaximumMatchingPairingStrategy.PairSocksContext() with cost %v returned no error", cost)
+		}
+		if len(got.Pairs)+len(got.Orphans)+len(got.Unprocessed) == 0 {
+			t.Errorf("MaximumMatchingPairingStrategy.PairSocksContext() with cost %v lost the basket", cost)
+		}
+	}
+}
+
+func TestMaximumMatchingPairingStrategy_PairSocks_neverMoreOrphans(t *testing.T) {
+	r := newRand(t)
+	colors := []string{"red", "crimson", "maroon", "brown"}
+	sides := []sockpair.Side{sockpair.Left, sockpair.Right, sockpair.Either}
+	matchers := []sockpair.Matcher{
+		sockpair.ExactMatcher{},
+		sockpair.SideAgnosticMatcher{},
+		nearColorMatcher{scale: colors},
+	}
+
+	for n := 0; n < 200; n++ {
+		basket := make(sockpair.Socks, r.Intn(16))
+		for i := range basket {
+			basket[i] = sockpair.Sock{
+				Color:   colors[r.Intn(len(colors))],
+				Pattern: "plain",
+				Side:    sides[r.Intn(len(sides))],
+			}
+		}
+
+		for _, matcher := range matchers {
+			want := sockpair.MaximumMatchingPairingStrategy{Matcher: matcher}.PairSocks(basket)
+			if got := len(want.Pairs)*2 + len(want.Orphans); got != len(basket) {
+				t.Fatalf("MaximumMatchingPairingStrategy.PairSocks(%v) accounted for %d socks, want %d", basket, got, len(basket))
+			}
+
+			for _, strategy := range []sockpair.SockPairingStrategy{
+				sockpair.RandomPairingStrategy{Rand: r, Matcher: matcher},
+				sockpair.SequentialPairingStrategy{Matcher: matcher},
+				sockpair.SortFirstPairingStrategy{Matcher: matcher},
+				sockpair.SurfacePairingStrategy{Matcher: matcher},
+			} {
+				if got := strategy.PairSocks(basket); len(got.Orphans) < len(want.Orphans) {
+					t.Errorf("%T.PairSocks(%v) left %d orphans, fewer than the %d left by MaximumMatchingPairingStrategy",
+						strategy, basket, len(got.Orphans), len(want.Orphans))
+				}
+			}
+		}
+	}
+}
+
+func TestPairMaximumMatching_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	basket := sockpair.GenerateSocks([]string{"red", "blue"}, []string{"plain"}, 2, false)
+
+	got, err := sockpair.PairMaximumMatching(ctx, basket, sockpair.SockRules(), sockpair.PairingOptions{})
+	if err != ctx.Err() {
+		t.Errorf("PairMaximumMatching() error = %v, want %v", err, ctx.Err())
+	}
+	unprocessed := sockpair.Socks(got.Unprocessed)
+	sort.Sort(unprocessed)
+	want := append(sockpair.Socks{}, basket...)
+	sort.Sort(want)
+	if len(got.Pairs) != 0 || len(got.Orphans) != 0 || !reflect.DeepEqual(unprocessed, want) {
+		t.Errorf("PairMaximumMatching() = %v, %v, %v, want every sock unprocessed", got.Pairs, got.Orphans, got.Unprocessed)
+	}
+}
+
+func BenchmarkMaximumMatchingPairingStrategy_PairSocks_noOrphans(b *testing.B) {
+	strategy := sockpair.MaximumMatchingPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		10,
+		false,
+	), newRand(b))
+	for i := 0; i < b.N; i++ {
+		strategy.PairSocks(testSocks)
+	}
+}
+
+func BenchmarkMaximumMatchingPairingStrategy_PairSocks_singleOrphan(b *testing.B) {
+	strategy := sockpair.MaximumMatchingPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(append(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		10,
+		false,
+	), sockpair.Sock{Color: "pink", Pattern: "plain", Side: sockpair.Left}), newRand(b))
+	for i := 0; i < b.N; i++ {
+		strategy.PairSocks(testSocks)
+	}
+}
+
+func BenchmarkMaximumMatchingPairingStrategy_PairSocks_allOrphans(b *testing.B) {
+	strategy := sockpair.MaximumMatchingPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		10,
+		true,
+	), newRand(b))
+	for i := 0; i < b.N; i++ {
+		strategy.PairSocks(testSocks)
+	}
+}
@@ -1,15 +1,124 @@
 package sock_pair_in_golang
 
+// Side is the foot a Sock is made for.
+type Side int
+
+const (
+	// Either is the Side of a Sock that fits either foot, such as a tube sock.
+	Either Side = iota
+	Left
+	Right
+)
+
+func (s Side) String() string {
+	switch s {
+	case Left:
+		return "left"
+	case Right:
+		return "right"
+	case Either:
+		return "either"
+	}
+	return "unknown"
+}
+
+// complements reports whether socks on sides s and s2 can make a pair. A left and a right Sock
+// make a pair, and a Sock that fits either foot can stand in for whichever side its partner lacks.
+func (s Side) complements(s2 Side) bool {
+	return s == Either || s2 == Either || s != s2
+}
+
+var (
+	leftPartners  = []Side{Right, Either}
+	rightPartners = []Side{Left, Either}
+	anyPartners   = []Side{Left, Right, Either}
+)
+
+// partners returns the sides that complement s, in the order a partner is looked for: the
+// opposite foot comes before Either, saving a Sock that fits either foot for one that only it
+// can pair.
+func (s Side) partners() []Side {
+	switch s {
+	case Left:
+		return leftPartners
+	case Right:
+		return rightPartners
+	}
+	return anyPartners
+}
+
+// rank orders sides as left, right, then either.
+func (s Side) rank() int {
+	switch s {
+	case Left:
+		return 0
+	case Right:
+		return 1
+	}
+	return 2
+}
+
+// Wear is the condition a Sock is in.
+type Wear int
+
+const (
+	// UnknownWear is the Wear of a Sock whose condition has not been noted.
+	UnknownWear Wear = iota
+	LikeNew
+	Worn
+	WornOut
+)
+
+func (w Wear) String() string {
+	switch w {
+	case UnknownWear:
+		return "unknown"
+	case LikeNew:
+		return "like new"
+	case Worn:
+		return "worn"
+	case WornOut:
+		return "worn out"
+	}
+	return "invalid"
+}
+
+// Sock is a single sock. Every attribute is optional; an attribute left as its zero value is
+// unspecified, and only pairs with socks where it is also unspecified.
 type Sock struct {
 	Color   string
 	Pattern string
-	IsLeft  bool
+	Side    Side
+	// Shade is the measured color of the Sock, used by ColorDistanceMatcher. It is unset for
+	// socks known only by the name of their Color.
+	Shade Shade
+	// Size is the size the Sock is made in, such as "M" or "child 4-6".
+	Size string
+	// Owner is the person the Sock belongs to.
+	Owner string
+	// Material is what the Sock is made of, such as "wool".
+	Material string
+	// Brand is the maker of the Sock.
+	Brand string
+	// Wear is the condition of the Sock. As socks wear unevenly, it is not compared by
+	// IsMatchingPair, but AttributeMatcher can be asked to compare it.
+	Wear Wear
+	// ID identifies the physical Sock, such as by the barcode on its label, so it can be told
+	// apart from identical socks and traced from one basket to the next. It is never compared
+	// when pairing.
+	ID string
 }
 
+// IsMatchingPair reports whether s and s2 are the same color, pattern, size, owner, material and
+// brand, and are made for opposite feet or either of them fits either foot.
 func (s *Sock) IsMatchingPair(s2 Sock) bool {
 	return s.Color == s2.Color &&
 		s.Pattern == s2.Pattern &&
-		s.IsLeft != s2.IsLeft
+		s.Size == s2.Size &&
+		s.Owner == s2.Owner &&
+		s.Material == s2.Material &&
+		s.Brand == s2.Brand &&
+		s.Side.complements(s2.Side)
 }
 
 type Socks []Sock
@@ -19,7 +128,12 @@ func (s Socks) Len() int {
 }
 
 func (s Socks) Less(i, j int) bool {
-	s1, s2 := s[i], s[j]
+	return lessSock(s[i], s[j])
+}
+
+// lessSock orders socks by color, then pattern, then size, owner, material, brand and wear, then
+// side, with left socks before right socks and socks that fit either foot last, then ID.
+func lessSock(s1, s2 Sock) bool {
 	if s1.Color != s2.Color {
 		return s1.Color < s2.Color
 	}
@@ -28,23 +142,101 @@ func (s Socks) Less(i, j int) bool {
 		return s1.Pattern < s2.Pattern
 	}
 
-	return s1.IsLeft
+	if s1.Size != s2.Size {
+		return s1.Size < s2.Size
+	}
+
+	if s1.Owner != s2.Owner {
+		return s1.Owner < s2.Owner
+	}
+
+	if s1.Material != s2.Material {
+		return s1.Material < s2.Material
+	}
+
+	if s1.Brand != s2.Brand {
+		return s1.Brand < s2.Brand
+	}
+
+	if s1.Wear != s2.Wear {
+		return s1.Wear < s2.Wear
+	}
+
+	if rank1, rank2 := s1.Side.rank(), s2.Side.rank(); rank1 != rank2 {
+		return rank1 < rank2
+	}
+
+	return s1.ID < s2.ID
 }
 
 func (s Socks) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
-type SockPairs []Socks
+// SockPair is a matched pair of socks, ordered left, right.
+type SockPair = Socks
+
+type SockPairs []SockPair
 
 func (s SockPairs) Len() int {
 	return len(s)
 }
 
 func (s SockPairs) Less(i, j int) bool {
-	return Socks{s[i][0], s[j][0]}.Less(0, 1)
+	return lessSock(s[i][0], s[j][0])
 }
 
 func (s SockPairs) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
+
+// PairingResult is the outcome of pairing a basket of socks.
+type PairingResult struct {
+	Pairs   SockPairs
+	Orphans Socks
+	// Unprocessed holds the socks a strategy had not finished with when it was stopped early.
+	// Unlike Orphans, they may still have a matching Sock among them. It is empty for a
+	// strategy that ran to completion.
+	Unprocessed Socks
+	Stats       PairingStats
+}
+
+// PairingStats counts the work a strategy did while pairing a basket, so strategies can be
+// compared by the effort a person would spend rather than by CPU time.
+type PairingStats struct {
+	// Comparisons is the number of times two socks were checked with IsMatchingPair.
+	Comparisons int
+	// Draws is the number of times a Sock was taken from the basket to be looked at.
+	Draws int
+	// Removals is the number of times a Sock was removed from the basket or the surface.
+	Removals int
+	// SurfacePeak is the largest number of socks laid out on the surface at once.
+	SurfacePeak int
+	// Placements is the number of times a Sock was laid out on the surface.
+	Placements int
+	// Scans is the number of times the surface was looked over for a Sock's partner.
+	Scans int
+	// SortComparisons is the number of comparisons made while sorting the basket.
+	SortComparisons int
+	// Steps is the number of iterations of the strategy's main loop.
+	Steps int
+	// Passes is the number of passes a strategy with limited space made through the basket and
+	// the socks it could not make room for. It is zero for strategies that go through the basket once.
+	Passes int
+}
+
+// add returns the sum of the stats s and s2. SurfacePeak, being a peak, is the larger of the two.
+func (s PairingStats) add(s2 PairingStats) PairingStats {
+	s.Comparisons += s2.Comparisons
+	s.Draws += s2.Draws
+	s.Removals += s2.Removals
+	if s2.SurfacePeak > s.SurfacePeak {
+		s.SurfacePeak = s2.SurfacePeak
+	}
+	s.Placements += s2.Placements
+	s.Scans += s2.Scans
+	s.SortComparisons += s2.SortComparisons
+	s.Steps += s2.Steps
+	s.Passes += s2.Passes
+	return s
+}
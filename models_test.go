@@ -0,0 +1,82 @@
+package sock_pair_in_golang
+
+import "testing"
+
+func TestSock_IsMatchingPair(t *testing.T) {
+	tests := []struct {
+		name string
+		s1   Sock
+		s2   Sock
+		want bool
+	}{
+		{"left, right", Sock{Color: "red", Pattern: "plain", Side: Left}, Sock{Color: "red", Pattern: "plain", Side: Right}, true},
+		{"right, left", Sock{Color: "red", Pattern: "plain", Side: Right}, Sock{Color: "red", Pattern: "plain", Side: Left}, true},
+		{"left, left", Sock{Color: "red", Pattern: "plain", Side: Left}, Sock{Color: "red", Pattern: "plain", Side: Left}, false},
+		{"right, right", Sock{Color: "red", Pattern: "plain", Side: Right}, Sock{Color: "red", Pattern: "plain", Side: Right}, false},
+		{"either, left", Sock{Color: "red", Pattern: "plain", Side: Either}, Sock{Color: "red", Pattern: "plain", Side: Left}, true},
+		{"right, either", Sock{Color: "red", Pattern: "plain", Side: Right}, Sock{Color: "red", Pattern: "plain", Side: Either}, true},
+		{"either, either", Sock{Color: "red", Pattern: "plain", Side: Either}, Sock{Color: "red", Pattern: "plain", Side: Either}, true},
+		{"different colors", Sock{Color: "red", Pattern: "plain", Side: Either}, Sock{Color: "blue", Pattern: "plain", Side: Either}, false},
+		{"different patterns", Sock{Color: "red", Pattern: "plain", Side: Left}, Sock{Color: "red", Pattern: "striped", Side: Right}, false},
+		{"different sizes", Sock{Color: "red", Size: "child", Side: Left}, Sock{Color: "red", Size: "adult", Side: Right}, false},
+		{"unspecified size", Sock{Color: "red", Size: "child", Side: Left}, Sock{Color: "red", Side: Right}, false},
+		{"different owners", Sock{Color: "red", Owner: "sam", Side: Left}, Sock{Color: "red", Owner: "alex", Side: Right}, false},
+		{"different materials", Sock{Color: "red", Material: "wool", Side: Left}, Sock{Color: "red", Material: "cotton", Side: Right}, false},
+		{"different brands", Sock{Color: "red", Brand: "acme", Side: Left}, Sock{Color: "red", Brand: "sockco", Side: Right}, false},
+		{"different IDs", Sock{ID: "a1", Color: "red", Side: Left}, Sock{ID: "b2", Color: "red", Side: Right}, true},
+		{"different wear", Sock{Color: "red", Wear: LikeNew, Side: Left}, Sock{Color: "red", Wear: WornOut, Side: Right}, true},
+		{"every attribute", Sock{Color: "red", Pattern: "plain", Size: "M", Owner: "sam", Material: "wool", Brand: "acme", Side: Left}, Sock{Color: "red", Pattern: "plain", Size: "M", Owner: "sam", Material: "wool", Brand: "acme", Side: Right}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s1.IsMatchingPair(tt.s2); got != tt.want {
+				t.Errorf("Sock.IsMatchingPair() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocks_Less(t *testing.T) {
+	tests := []struct {
+		name string
+		s1   Sock
+		s2   Sock
+		want bool
+	}{
+		{"color first", Sock{Color: "blue", Pattern: "striped", Side: Either}, Sock{Color: "red", Pattern: "plain", Side: Left}, true},
+		{"then pattern", Sock{Color: "red", Pattern: "plain", Side: Either}, Sock{Color: "red", Pattern: "striped", Side: Left}, true},
+		{"then size", Sock{Color: "red", Pattern: "plain", Size: "L", Side: Either}, Sock{Color: "red", Pattern: "plain", Size: "S", Side: Left}, true},
+		{"then owner", Sock{Color: "red", Owner: "alex", Side: Either}, Sock{Color: "red", Owner: "sam", Side: Left}, true},
+		{"then material", Sock{Color: "red", Material: "cotton", Side: Either}, Sock{Color: "red", Material: "wool", Side: Left}, true},
+		{"then brand", Sock{Color: "red", Brand: "acme", Side: Either}, Sock{Color: "red", Brand: "sockco", Side: Left}, true},
+		{"then wear", Sock{Color: "red", Wear: LikeNew, Side: Either}, Sock{Color: "red", Wear: Worn, Side: Left}, true},
+		{"left before right", Sock{Color: "red", Pattern: "plain", Side: Left}, Sock{Color: "red", Pattern: "plain", Side: Right}, true},
+		{"right before either", Sock{Color: "red", Pattern: "plain", Side: Right}, Sock{Color: "red", Pattern: "plain", Side: Either}, true},
+		{"either after left", Sock{Color: "red", Pattern: "plain", Side: Either}, Sock{Color: "red", Pattern: "plain", Side: Left}, false},
+		{"then ID", Sock{ID: "a1", Color: "red", Side: Left}, Sock{ID: "b2", Color: "red", Side: Left}, true},
+		{"left not before left", Sock{Color: "red", Pattern: "plain", Side: Left}, Sock{Color: "red", Pattern: "plain", Side: Left}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Socks{tt.s1, tt.s2}).Less(0, 1); got != tt.want {
+				t.Errorf("Socks.Less() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSide_String(t *testing.T) {
+	for side, want := range map[Side]string{Left: "left", Right: "right", Either: "either", Side(7): "unknown"} {
+		if got := side.String(); got != want {
+			t.Errorf("Side(%d).String() = %q, want %q", side, got, want)
+		}
+	}
+}
+
+func TestWear_String(t *testing.T) {
+	for wear, want := range map[Wear]string{UnknownWear: "unknown", LikeNew: "like new", Worn: "worn", WornOut: "worn out", Wear(7): "invalid"} {
+		if got := wear.String(); got != want {
+			t.Errorf("Wear(%d).String() = %q, want %q", wear, got, want)
+		}
+	}
+}
@@ -0,0 +1,472 @@
+package sock_pair_in_golang
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The notation writes a Sock as its color, pattern and side separated by slashes, followed by
+// any other attributes that are set as key=value fields, such as
+//
+//	red/plain/L
+//	navy/ribbed/R/id=rfid-0042/size=child/wear="worn out"
+//
+// The side is L, R or E for either, and may be left out to mean either. A Side or Wear with no
+// name of its own is written as its number, so that every Sock reads back as it was written. A Shade is written as its
+// L, A and B coordinates separated by colons, such as shade=13:47.5:-64.5. Values containing
+// spaces or any of / , + = " are quoted, as in Go.
+//
+// A basket lists its socks separated by commas or newlines, with xN after a Sock to repeat it
+// N times, such as "red/plain/L x3, blue/striped/R". Pairs are written as their two socks joined
+// by a plus sign, such as "red/plain/L + red/plain/R".
+
+// notationKeys are the keys of the attributes written as key=value fields, in the order written.
+var notationKeys = []string{"id", "shade", "size", "owner", "material", "brand", "wear"}
+
+var sideLetters = map[Side]string{Either: "E", Left: "L", Right: "R"}
+
+// String returns s in the notation read by ParseSocks.
+func (s Sock) String() string {
+	var b strings.Builder
+	b.WriteString(notationValue(s.Color))
+	b.WriteByte('/')
+	b.WriteString(notationValue(s.Pattern))
+	b.WriteByte('/')
+	if letter, ok := sideLetters[s.Side]; ok {
+		b.WriteString(letter)
+	} else {
+		b.WriteString(strconv.Itoa(int(s.Side)))
+	}
+
+	for _, key := range notationKeys {
+		value := ""
+		switch key {
+		case "id":
+			value = s.ID
+		case "shade":
+			if lab, ok := s.Shade.Lab(); ok {
+				value = strings.Join([]string{
+					strconv.FormatFloat(lab.L, 'g', -1, 64),
+					strconv.FormatFloat(lab.A, 'g', -1, 64),
+					strconv.FormatFloat(lab.B, 'g', -1, 64),
+				}, ":")
+			}
+		case "size":
+			value = s.Size
+		case "owner":
+			value = s.Owner
+		case "material":
+			value = s.Material
+		case "brand":
+			value = s.Brand
+		case "wear":
+			if text, err := s.Wear.MarshalText(); err != nil {
+				value = strconv.Itoa(int(s.Wear))
+			} else if s.Wear != UnknownWear {
+				value = string(text)
+			}
+		}
+		if value != "" {
+			b.WriteString("/" + key + "=" + notationValue(value))
+		}
+	}
+	return b.String()
+}
+
+// String returns the socks on one line in the notation read by ParseSocks, in order, with
+// runs of the same Sock written once with a count.
+func (s Socks) String() string {
+	items := make([]string, 0, len(s))
+	for i := 0; i < len(s); {
+		run := 1
+		for i+run < len(s) && s[i+run] == s[i] {
+			run++
+		}
+		items = append(items, notationItem(s[i], run))
+		i += run
+	}
+	return strings.Join(items, ", ")
+}
+
+// String returns the pairs on one line in the notation read by ParseSockPairs.
+func (p SockPairs) String() string {
+	items := make([]string, len(p))
+	for i, pair := range p {
+		socks := make([]string, len(pair))
+		for j, s := range pair {
+			socks[j] = s.String()
+		}
+		items[i] = strings.Join(socks, " + ")
+	}
+	return strings.Join(items, ", ")
+}
+
+// FormatSocks pretty-prints a basket in the notation read by ParseSocks, with one line for each
+// kind of Sock in sorted order, and the counts of socks that appear more than once lined up.
+func FormatSocks(socks Socks) string {
+	sorted := append(Socks{}, socks...)
+	sort.Sort(sorted)
+
+	kinds := make([]string, 0)
+	counts := make([]int, 0)
+	width := 0
+	for i := 0; i < len(sorted); {
+		run := 1
+		for i+run < len(sorted) && sorted[i+run] == sorted[i] {
+			run++
+		}
+		kind := sorted[i].String()
+		kinds = append(kinds, kind)
+		counts = append(counts, run)
+		if n := utf8.RuneCountInString(kind); n > width {
+			width = n
+		}
+		i += run
+	}
+
+	var b strings.Builder
+	for i, kind := range kinds {
+		b.WriteString(kind)
+		if counts[i] > 1 {
+			b.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(kind)+1))
+			b.WriteString("x" + strconv.Itoa(counts[i]))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func notationItem(s Sock, count int) string {
+	if count == 1 {
+		return s.String()
+	}
+	return s.String() + " x" + strconv.Itoa(count)
+}
+
+// notationValue returns value as written in the notation, quoted if it would otherwise be misread.
+func notationValue(value string) string {
+	if strings.ContainsAny(value, notationSpecial) || strings.IndexFunc(value, isNotationSpace) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// notationSpecial are the characters that end an unquoted value.
+const notationSpecial = "/,+=\""
+
+func isNotationSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\r' || r == '\n'
+}
+
+// SyntaxError reports where text written in the notation could not be parsed.
+type SyntaxError struct {
+	// Line and Column are the position of the error, counting from 1. Columns count characters.
+	Line, Column int
+	Msg          string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// maxNotationSocks is the most socks ParseSocks returns, so that a short text such as
+// "red/plain/L x1000000000" cannot exhaust memory.
+const maxNotationSocks = 100000
+
+// ParseSocks parses a basket written in the notation, such as "red/plain/L x3, blue/striped/R".
+// It returns a *SyntaxError if text is not written in the notation, or holds more than a hundred
+// thousand socks.
+func ParseSocks(text string) (Socks, error) {
+	p := newNotationParser(text)
+	socks := make(Socks, 0)
+	err := p.parseList(func() error {
+		s, err := p.parseSock()
+		if err != nil {
+			return err
+		}
+		count, err := p.parseCount(maxNotationSocks - len(socks))
+		if err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			socks = append(socks, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return socks, nil
+}
+
+// MustParseSocks is like ParseSocks but panics if text cannot be parsed. It simplifies writing
+// baskets in tests.
+func MustParseSocks(text string) Socks {
+	socks, err := ParseSocks(text)
+	if err != nil {
+		panic("sock_pair_in_golang: ParseSocks(" + strconv.Quote(text) + "): " + err.Error())
+	}
+	return socks
+}
+
+// ParseSockPairs parses pairs written in the notation, such as "red/plain/L + red/plain/R".
+// It returns a *SyntaxError if text is not written in the notation.
+func ParseSockPairs(text string) (SockPairs, error) {
+	p := newNotationParser(text)
+	pairs := make(SockPairs, 0)
+	err := p.parseList(func() error {
+		a, err := p.parseSock()
+		if err != nil {
+			return err
+		}
+		p.skipSpace()
+		if p.peek() != '+' {
+			return p.errorf("expected + between the socks of a pair")
+		}
+		p.next()
+		p.skipSpace()
+		b, err := p.parseSock()
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, SockPair{a, b})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// notationParser reads the notation one character at a time, keeping track of its position.
+type notationParser struct {
+	text   string
+	offset int
+	line   int
+	column int
+}
+
+func newNotationParser(text string) *notationParser {
+	return &notationParser{text: text, line: 1, column: 1}
+}
+
+// eof is returned by peek at the end of the text.
+const eof = -1
+
+func (p *notationParser) peek() rune {
+	if p.offset >= len(p.text) {
+		return eof
+	}
+	r, _ := utf8.DecodeRuneInString(p.text[p.offset:])
+	return r
+}
+
+func (p *notationParser) next() rune {
+	if p.offset >= len(p.text) {
+		return eof
+	}
+	r, size := utf8.DecodeRuneInString(p.text[p.offset:])
+	p.offset += size
+	if r == '\n' {
+		p.line++
+		p.column = 1
+	} else {
+		p.column++
+	}
+	return r
+}
+
+// skipSpace skips spaces and tabs, but not newlines.
+func (p *notationParser) skipSpace() {
+	for r := p.peek(); r == ' ' || r == '\t' || r == '\r'; r = p.peek() {
+		p.next()
+	}
+}
+
+// skipBlank skips spaces, tabs and newlines.
+func (p *notationParser) skipBlank() {
+	for isNotationSpace(p.peek()) {
+		p.next()
+	}
+}
+
+func (p *notationParser) errorf(format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{Line: p.line, Column: p.column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parseList calls parseItem for each item of a list separated by commas or newlines. Blank
+// lines and a trailing comma are allowed.
+func (p *notationParser) parseList(parseItem func() error) error {
+	p.skipBlank()
+	for p.peek() != eof {
+		if err := parseItem(); err != nil {
+			return err
+		}
+
+		p.skipSpace()
+		switch p.peek() {
+		case eof:
+		case ',', '\n':
+			p.next()
+			p.skipBlank()
+		default:
+			return p.errorf("expected , or a new line after a sock, found %q", p.peek())
+		}
+	}
+	return nil
+}
+
+// parseSock parses a Sock such as red/plain/L/owner=sam.
+func (p *notationParser) parseSock() (Sock, error) {
+	var s Sock
+	var err error
+	if s.Color, err = p.parseValue(); err != nil {
+		return Sock{}, err
+	}
+	if p.peek() != '/' {
+		return Sock{}, p.errorf("expected / after the color")
+	}
+	p.next()
+	if s.Pattern, err = p.parseValue(); err != nil {
+		return Sock{}, err
+	}
+
+	for field := 0; p.peek() == '/'; field++ {
+		p.next()
+		line, column := p.line, p.column
+		value, err := p.parseValue()
+		if err != nil {
+			return Sock{}, err
+		}
+
+		if p.peek() != '=' {
+			if field > 0 {
+				return Sock{}, &SyntaxError{Line: line, Column: column, Msg: "expected key=value after the side"}
+			}
+			if s.Side, err = parseSideLetter(value); err != nil {
+				return Sock{}, &SyntaxError{Line: line, Column: column, Msg: err.Error()}
+			}
+			continue
+		}
+
+		p.next()
+		key := value
+		if value, err = p.parseValue(); err != nil {
+			return Sock{}, err
+		}
+		if err := setNotationField(&s, key, value); err != nil {
+			return Sock{}, &SyntaxError{Line: line, Column: column, Msg: err.Error()}
+		}
+	}
+	return s, nil
+}
+
+// parseValue parses a value, which is either quoted or runs up to a space or one of the
+// notation's special characters.
+func (p *notationParser) parseValue() (string, error) {
+	start := p.offset
+	if p.peek() != '"' {
+		for r := p.peek(); r != eof && !isNotationSpace(r) && !strings.ContainsRune(notationSpecial, r); r = p.peek() {
+			p.next()
+		}
+		return p.text[start:p.offset], nil
+	}
+
+	line, column := p.line, p.column
+	p.next()
+	for {
+		switch p.next() {
+		case eof, '\n':
+			return "", &SyntaxError{Line: line, Column: column, Msg: "unterminated quoted value"}
+		case '\\':
+			p.next()
+		case '"':
+			value, err := strconv.Unquote(p.text[start:p.offset])
+			if err != nil {
+				return "", &SyntaxError{Line: line, Column: column, Msg: "invalid quoted value " + p.text[start:p.offset]}
+			}
+			return value, nil
+		}
+	}
+}
+
+// parseCount parses the xN count that may follow a Sock, returning 1 if there is none. It returns
+// an error if the count is more than limit.
+func (p *notationParser) parseCount(limit int) (int, error) {
+	p.skipSpace()
+	line, column := p.line, p.column
+	tooMany := &SyntaxError{Line: line, Column: column, Msg: fmt.Sprintf("too many socks, want at most %d in a basket", maxNotationSocks)}
+	if p.peek() != 'x' {
+		if limit < 1 {
+			return 0, tooMany
+		}
+		return 1, nil
+	}
+	p.next()
+	start := p.offset
+	for r := p.peek(); r >= '0' && r <= '9'; r = p.peek() {
+		p.next()
+	}
+	count, err := strconv.Atoi(p.text[start:p.offset])
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		return 0, tooMany
+	}
+	if err != nil || count < 1 {
+		return 0, &SyntaxError{Line: line, Column: column, Msg: "expected a count such as x2"}
+	}
+	if count > limit {
+		return 0, tooMany
+	}
+	return count, nil
+}
+
+func parseSideLetter(letter string) (Side, error) {
+	for side, l := range sideLetters {
+		if strings.EqualFold(letter, l) {
+			return side, nil
+		}
+	}
+	if n, err := strconv.Atoi(letter); err == nil {
+		return Side(n), nil
+	}
+	return Either, fmt.Errorf("invalid side %q, want L, R or E", letter)
+}
+
+// setNotationField sets the attribute of s named by key to value.
+func setNotationField(s *Sock, key, value string) error {
+	switch key {
+	case "id":
+		s.ID = value
+	case "shade":
+		coordinates := strings.Split(value, ":")
+		lab := make([]float64, len(coordinates))
+		for i, c := range coordinates {
+			f, err := strconv.ParseFloat(c, 64)
+			if err != nil || len(coordinates) != 3 {
+				return fmt.Errorf("invalid shade %q, want L:A:B", value)
+			}
+			lab[i] = f
+		}
+		s.Shade = Lab{L: lab[0], A: lab[1], B: lab[2]}.Shade()
+	case "size":
+		s.Size = value
+	case "owner":
+		s.Owner = value
+	case "material":
+		s.Material = value
+	case "brand":
+		s.Brand = value
+	case "wear":
+		if n, err := strconv.Atoi(value); err == nil {
+			s.Wear = Wear(n)
+			return nil
+		}
+		return s.Wear.UnmarshalText([]byte(value))
+	default:
+		return fmt.Errorf("unknown attribute %q, want one of %s", key, strings.Join(notationKeys, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package sock_pair_in_golang_test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestSock_String(t *testing.T) {
+	tests := []struct {
+		sock sockpair.Sock
+		want string
+	}{
+		{sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Left}, "red/plain/L"},
+		{sockpair.Sock{Color: "red", Side: sockpair.Either}, "red//E"},
+		{sockpair.Sock{}, "//E"},
+		{encodingBasket[2], `navy/"ribbed, with a heel"/R/id=rfid-0042/shade=13:47.5:-64.5/size=child/owner=sam/material=wool/brand=acme/wear="worn out"`},
+		{sockpair.Sock{Color: "a/b", Pattern: `say "hi"`, Side: sockpair.Right, Owner: "x=y"}, `"a/b"/"say \"hi\""/R/owner="x=y"`},
+		{sockpair.Sock{Color: "red", Side: 7, Wear: -1}, "red//7/wear=-1"},
+	}
+	for _, tt := range tests {
+		if got := tt.sock.String(); got != tt.want {
+			t.Errorf("Sock.String() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestSocks_String(t *testing.T) {
+	basket := sockpair.Socks{
+		{Color: "red", Pattern: "plain", Side: sockpair.Left},
+		{Color: "red", Pattern: "plain", Side: sockpair.Left},
+		{Color: "red", Pattern: "plain", Side: sockpair.Left},
+		{Color: "blue", Pattern: "striped", Side: sockpair.Right},
+		{Color: "red", Pattern: "plain", Side: sockpair.Left},
+	}
+	if got, want := basket.String(), "red/plain/L x3, blue/striped/R, red/plain/L"; got != want {
+		t.Errorf("Socks.String() = %s, want %s", got, want)
+	}
+	if got := (sockpair.Socks{}).String(); got != "" {
+		t.Errorf("Socks{}.String() = %q, want \"\"", got)
+	}
+}
+
+func TestSocks_String_roundTrip(t *testing.T) {
+	generated := sockpair.ShuffleSocksWithRand(sockpair.AssignIDs(sockpair.GenerateVariedSocks(sockpair.Variety{
+		Colors:   []string{"red", "dark blue"},
+		Patterns: []string{"plain", "argyle/diamond"},
+		Owners:   []string{"sam", ""},
+		Wears:    []sockpair.Wear{sockpair.LikeNew, sockpair.UnknownWear},
+	}, 2, false), "sock"), newRand(t))
+
+	invalid := sockpair.Socks{
+		{Color: "red", Side: 7},
+		{Color: "red", Side: -1, Wear: 9},
+	}
+
+	for _, basket := range []sockpair.Socks{encodingBasket, generated, invalid, {}} {
+		got, err := sockpair.ParseSocks(basket.String())
+		if err != nil {
+			t.Fatalf("ParseSocks(%s) error = %v", basket, err)
+		}
+		if !reflect.DeepEqual(got, basket) {
+			t.Errorf("ParseSocks(%s) = %v, want %v", basket, got, basket)
+		}
+
+		formatted, err := sockpair.ParseSocks(sockpair.FormatSocks(basket))
+		if err != nil {
+			t.Fatalf("ParseSocks(FormatSocks()) error = %v", err)
+		}
+		if !reflect.DeepEqual(sortedSocks(formatted), sortedSocks(basket)) {
+			t.Errorf("ParseSocks(FormatSocks()) = %v, want %v", formatted, basket)
+		}
+	}
+}
+
+func TestSockPairs_String(t *testing.T) {
+	want := `red/plain/L + red/plain/R, navy/"ribbed, with a heel"/R/id=rfid-0042/shade=13:47.5:-64.5/size=child/owner=sam/material=wool/brand=acme/wear="worn out" + ` +
+		`navy/"ribbed, with a heel"/L/id=rfid-0043/size=child/owner=sam/material=wool/brand=acme, //E + "\"quoted\""/"- dashed: yes"/E`
+	if got := encodingPairs.String(); got != want {
+		t.Errorf("SockPairs.String() = %s, want %s", got, want)
+	}
+
+	got, err := sockpair.ParseSockPairs(encodingPairs.String())
+	if err != nil {
+		t.Fatalf("ParseSockPairs() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, encodingPairs) {
+		t.Errorf("ParseSockPairs() = %v, want %v", got, encodingPairs)
+	}
+}
+
+func TestParseSocks(t *testing.T) {
+	red := sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Left}
+	blue := sockpair.Sock{Color: "blue", Pattern: "striped", Side: sockpair.Right}
+	tests := []struct {
+		name string
+		text string
+		want sockpair.Socks
+	}{
+		{"counts", "red/plain/L x3, blue/striped/R", sockpair.Socks{red, red, red, blue}},
+		{"lines", "\n  red/plain/L  x2\n\nblue/striped/R,\n", sockpair.Socks{red, red, blue}},
+		{"empty", "  \n", sockpair.Socks{}},
+		{"lower case side", "red/plain/l", sockpair.Socks{red}},
+		{"no side", "red/plain", sockpair.Socks{{Color: "red", Pattern: "plain"}}},
+		{"no side with attributes", "red/plain/owner=sam", sockpair.Socks{{Color: "red", Pattern: "plain", Owner: "sam"}}},
+		{"quoted", `"dark blue"/"a, b"/R`, sockpair.Socks{{Color: "dark blue", Pattern: "a, b", Side: sockpair.Right}}},
+		{"wear", `red/plain/L/wear=worn`, sockpair.Socks{{Color: "red", Pattern: "plain", Side: sockpair.Left, Wear: sockpair.Worn}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sockpair.ParseSocks(tt.text)
+			if err != nil {
+				t.Fatalf("ParseSocks() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSocks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSocks_errors(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"red", "line 1, column 4: expected / after the color"},
+		{"red/plain/L,\nblue/striped/Q", `line 2, column 14: invalid side "Q", want L, R or E`},
+		{"red/plain/L blue/striped/R", `line 1, column 13: expected , or a new line after a sock, found 'b'`},
+		{"red/plain/L x", "line 1, column 13: expected a count such as x2"},
+		{"red/plain/L x0", "line 1, column 13: expected a count such as x2"},
+		{"red/plain/L x1000000000", "line 1, column 13: too many socks, want at most 100000 in a basket"},
+		{"red/plain/L x99999999999999999999", "line 1, column 13: too many socks, want at most 100000 in a basket"},
+		{"red/plain/L x99999, blue/plain/R x2", "line 1, column 34: too many socks, want at most 100000 in a basket"},
+		{"red/plain/L x100000\nblue/plain/R", "line 2, column 13: too many socks, want at most 100000 in a basket"},
+		{"red/plain/L/colour=red", `line 1, column 13: unknown attribute "colour", want one of id, shade, size, owner, material, brand, wear`},
+		{"red/plain/L/owner=sam/R", "line 1, column 23: expected key=value after the side"},
+		{"red/plain/L/shade=1:2", `line 1, column 13: invalid shade "1:2", want L:A:B`},
+		{"red/plain/L/wear=shredded", `line 1, column 13: invalid wear "shredded"`},
+		{`red/"plain/L`, "line 1, column 5: unterminated quoted value"},
+		{"réd/plain/L/size", "line 1, column 13: expected key=value after the side"},
+	}
+	for _, tt := range tests {
+		_, err := sockpair.ParseSocks(tt.text)
+		var syntaxErr *sockpair.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("ParseSocks(%q) error = %v, want a SyntaxError", tt.text, err)
+			continue
+		}
+		if err.Error() != tt.want {
+			t.Errorf("ParseSocks(%q) error = %v, want %s", tt.text, err, tt.want)
+		}
+	}
+}
+
+func TestParseSockPairs_errors(t *testing.T) {
+	for _, text := range []string{"red/plain/L", "red/plain/L + ", "red/plain/L + red/plain/R x2"} {
+		if _, err := sockpair.ParseSockPairs(text); err == nil {
+			t.Errorf("ParseSockPairs(%q) returned no error", text)
+		}
+	}
+}
+
+func TestFormatSocks(t *testing.T) {
+	basket := sockpair.MustParseSocks("red/plain/R, blue/striped/L/owner=sam x2, red/plain/L x3, red/plain/R")
+	want := "blue/striped/L/owner=sam x2\n" +
+		"red/plain/L              x3\n" +
+		"red/plain/R              x2\n"
+	if got := sockpair.FormatSocks(basket); got != want {
+		t.Errorf("FormatSocks() = %q, want %q", got, want)
+	}
+}
+
+func TestMustParseSocks(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParseSocks() of an invalid basket did not panic")
+		}
+	}()
+	sockpair.MustParseSocks("red")
+}
+
+func sortedSocks(socks sockpair.Socks) sockpair.Socks {
+	sorted := append(sockpair.Socks{}, socks...)
+	sort.Sort(sorted)
+	return sorted
+}
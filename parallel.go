@@ -0,0 +1,102 @@
+package sock_pair_in_golang
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// ParallelPairingStrategy is the process of dealing the basket out between several people, each
+// of whom pairs their share of the socks at the same time. Socks are dealt out by match key, so
+// socks that pair always end up with the same person. As each person lays out their own surface,
+// the SurfacePeak of its stats is the sum of the peaks of every surface. It never modifies the basket.
+type ParallelPairingStrategy struct {
+	// Workers is the number of goroutines pairing at once. Zero uses runtime.GOMAXPROCS.
+	Workers int
+	// Matcher decides the match key socks are dealt out by, and should be the Matcher used by
+	// Inner. A nil Matcher uses ExactMatcher.
+	Matcher Matcher
+	// Inner pairs each share of the basket. It is run from several goroutines at once, so must
+	// be safe for concurrent use; a RandomPairingStrategy must not share its Rand. A nil Inner
+	// uses SurfacePairingStrategy with Matcher.
+	Inner SockPairingStrategy
+}
+
+func (s ParallelPairingStrategy) PairSocks(freshSocks Socks) PairingResult {
+	res, _ := s.PairSocksContext(context.Background(), freshSocks)
+	return res
+}
+
+func (s ParallelPairingStrategy) PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error) {
+	workers := s.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	matcher := s.Matcher
+	if matcher == nil {
+		matcher = ExactMatcher{}
+	}
+	inner := s.Inner
+	if inner == nil {
+		inner = SurfacePairingStrategy{Matcher: matcher}
+	}
+
+	shards := make([]Socks, workers)
+	for _, sock := range freshSocks {
+		shard := hashSock(matcher.MatchKey(sock)) % uint64(workers)
+		shards[shard] = append(shards[shard], sock)
+	}
+
+	results := make([]PairingResult, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i, shard := range shards {
+		wg.Add(1)
+		go func(i int, shard Socks) {
+			defer wg.Done()
+			results[i], errs[i] = PairContext(ctx, inner, shard)
+		}(i, shard)
+	}
+	wg.Wait()
+
+	// merge the shares in the order they were dealt, so the result only depends on the basket
+	res := PairingResult{Pairs: make(SockPairs, 0), Orphans: make(Socks, 0)}
+	surfacePeak := 0
+	var err error
+	for i, shardRes := range results {
+		res.Pairs = append(res.Pairs, shardRes.Pairs...)
+		res.Orphans = append(res.Orphans, shardRes.Orphans...)
+		res.Unprocessed = append(res.Unprocessed, shardRes.Unprocessed...)
+		res.Stats = res.Stats.add(shardRes.Stats)
+		surfacePeak += shardRes.Stats.SurfacePeak
+		if err == nil {
+			err = errs[i]
+		}
+	}
+	// the shares are laid out at the same time, so the room needed is that of every surface
+	res.Stats.SurfacePeak = surfacePeak
+	return res, err
+}
+
+// hashSock returns a hash of every attribute of s.
+func hashSock(s Sock) uint64 {
+	h := fnv.New64a()
+	for _, field := range []string{s.ID, s.Color, s.Pattern, s.Size, s.Owner, s.Material, s.Brand} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	for _, n := range []int{int(s.Side), int(s.Wear)} {
+		h.Write([]byte(strconv.Itoa(n)))
+		h.Write([]byte{0})
+	}
+	if lab, ok := s.Shade.Lab(); ok {
+		for _, f := range []float64{lab.L, lab.A, lab.B} {
+			h.Write([]byte(strconv.FormatUint(math.Float64bits(f), 16)))
+			h.Write([]byte{0})
+		}
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,110 @@
+package sock_pair_in_golang_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/sockpairtest"
+)
+
+func TestParallelPairingStrategy_PairSocks(t *testing.T) {
+	// RandomPairingStrategy is left out, as it gives up on socks after too many unlucky draws
+	inners := []sockpair.SockPairingStrategy{
+		nil,
+		sockpair.SequentialPairingStrategy{},
+		sockpair.SortFirstPairingStrategy{},
+		sockpair.MaximumMatchingPairingStrategy{},
+	}
+	for _, inner := range inners {
+		for _, workers := range []int{0, 1, 2, 7} {
+			t.Run(fmt.Sprintf("%T/%d", inner, workers), func(t *testing.T) {
+				sockpairtest.TestStrategy(t, sockpair.ParallelPairingStrategy{Workers: workers, Inner: inner})
+			})
+		}
+	}
+}
+
+func TestParallelPairingStrategy_PairSocks_accountsForEverySock(t *testing.T) {
+	r := newRand(t)
+	for n := 0; n < 100; n++ {
+		g := sockpair.BasketGenerator{Variety: rainbow, Pairs: r.Intn(40), LossProbability: 0.2, Zipf: 1, Rand: r}
+		basket, _ := g.Generate()
+		sockpair.AssignIDs(basket, "sock")
+		want := sockpair.SurfacePairingStrategy{}.PairSocks(basket)
+
+		strategy := sockpair.ParallelPairingStrategy{Workers: 1 + r.Intn(8)}
+		got := strategy.PairSocks(basket)
+		if err := sockpair.Audit(basket, got); err != nil {
+			t.Fatalf("Audit(%+v.PairSocks()) error = %v", strategy, err)
+		}
+		// socks that pair are always in the same share, so no pair is missed
+		if len(got.Orphans) != len(want.Orphans) {
+			t.Errorf("%+v.PairSocks() orphans = %d, want %d", strategy, len(got.Orphans), len(want.Orphans))
+		}
+	}
+}
+
+func TestParallelPairingStrategy_PairSocks_surfacePeak(t *testing.T) {
+	// MaximumMatchingPairingStrategy lays its whole share out at once, so every sock in the basket
+	// is on one surface or another at the same time
+	basket := sockpair.GenerateSocks([]string{"red", "blue", "green", "pink"}, []string{"plain", "striped"}, 2, false)
+	strategy := sockpair.ParallelPairingStrategy{Workers: 4, Inner: sockpair.MaximumMatchingPairingStrategy{}}
+	if got := strategy.PairSocks(basket).Stats.SurfacePeak; got != len(basket) {
+		t.Errorf("ParallelPairingStrategy.PairSocks() SurfacePeak = %d, want %d", got, len(basket))
+	}
+}
+
+func TestParallelPairingStrategy_PairSocks_matcher(t *testing.T) {
+	basket := sockpair.Socks{
+		{Color: "navy", Side: sockpair.Left},
+		{Color: "dark blue", Side: sockpair.Right},
+		{Color: "red", Side: sockpair.Left},
+		{Color: "Red", Side: sockpair.Right},
+	}
+	strategy := sockpair.ParallelPairingStrategy{Workers: 4, Matcher: sockpair.ColorDistanceMatcher{}}
+	got := strategy.PairSocks(basket)
+	if len(got.Pairs) != 2 || len(got.Orphans) != 0 {
+		t.Errorf("ParallelPairingStrategy.PairSocks() = %v, %v, want 2 pairs", got.Pairs, got.Orphans)
+	}
+}
+
+func TestParallelPairingStrategy_PairSocksContext_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	basket := sockpair.GenerateSocks([]string{"red", "blue", "green"}, []string{"plain"}, 2, false)
+
+	got, err := sockpair.ParallelPairingStrategy{Workers: 3}.PairSocksContext(ctx, basket)
+	if err != ctx.Err() {
+		t.Errorf("ParallelPairingStrategy.PairSocksContext() error = %v, want %v", err, ctx.Err())
+	}
+	unprocessed := append(sockpair.Socks{}, got.Unprocessed...)
+	sort.Sort(unprocessed)
+	want := append(sockpair.Socks{}, basket...)
+	sort.Sort(want)
+	if len(got.Pairs) != 0 || len(got.Orphans) != 0 || !reflect.DeepEqual(unprocessed, want) {
+		t.Errorf("ParallelPairingStrategy.PairSocksContext() = %v, %v, %v, want every sock unprocessed", got.Pairs, got.Orphans, got.Unprocessed)
+	}
+}
+
+func BenchmarkParallelPairingStrategy_PairSocks_workers(b *testing.B) {
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateVariedSocks(sockpair.Variety{
+		Colors:   []string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		Patterns: []string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		Owners:   []string{"sam", "alex", "jo", "kim", "lee", "max", "pat", "ray"},
+	}, 4, false), newRand(b))
+	inners := []sockpair.SockPairingStrategy{sockpair.SequentialPairingStrategy{}, sockpair.SurfacePairingStrategy{}}
+	for _, inner := range inners {
+		for _, workers := range []int{1, 2, 4, 8} {
+			strategy := sockpair.ParallelPairingStrategy{Workers: workers, Inner: inner}
+			b.Run(fmt.Sprintf("%T/%d", inner, workers), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					strategy.PairSocks(testSocks)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package sock_pair_in_golang
+
+import "fmt"
+
+// AssignIDs gives every Sock in socks without an ID one made from the prefix and its position,
+// such as "load1-3", so identical socks can be told apart. It returns socks for convenience.
+func AssignIDs(socks Socks, prefix string) Socks {
+	for i := range socks {
+		if socks[i].ID == "" {
+			socks[i].ID = fmt.Sprintf("%s-%d", prefix, i+1)
+		}
+	}
+	return socks
+}
+
+// Audit checks that res accounts for every Sock in basket exactly once, across its pairs, orphans
+// and unprocessed socks, and that no two socks in basket share an ID. Socks are told apart by ID,
+// and socks without an ID by their attributes.
+func Audit(basket Socks, res PairingResult) error {
+	ids := make(map[string]bool)
+	for _, s := range basket {
+		if s.ID == "" {
+			continue
+		}
+		if ids[s.ID] {
+			return fmt.Errorf("sock ID %q appears more than once in the basket", s.ID)
+		}
+		ids[s.ID] = true
+	}
+
+	counts := make(map[Sock]int)
+	for _, s := range basket {
+		counts[s]++
+	}
+	account := func(s Sock) error {
+		if counts[s] == 0 {
+			return fmt.Errorf("sock %v is not in the basket, or is accounted for more than once", s)
+		}
+		counts[s]--
+		return nil
+	}
+
+	for _, pair := range res.Pairs {
+		if len(pair) != 2 {
+			return fmt.Errorf("pair %v does not hold two socks", pair)
+		}
+		for _, s := range pair {
+			if err := account(s); err != nil {
+				return err
+			}
+		}
+	}
+	for _, socks := range []Socks{res.Orphans, res.Unprocessed} {
+		for _, s := range socks {
+			if err := account(s); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, s := range basket {
+		if counts[s] > 0 {
+			return fmt.Errorf("sock %v is not accounted for", s)
+		}
+	}
+	return nil
+}
+
+// EventKind is what became of a Sock when its basket was paired.
+type EventKind int
+
+const (
+	Paired EventKind = iota
+	Orphaned
+	// Unprocessed is the EventKind of a Sock left untouched by a strategy that was stopped early.
+	Unprocessed
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case Paired:
+		return "paired"
+	case Orphaned:
+		return "orphaned"
+	case Unprocessed:
+		return "unprocessed"
+	}
+	return "unknown"
+}
+
+// Event records what became of a Sock when the basket named Load was paired.
+type Event struct {
+	Load string
+	Kind EventKind
+	Sock Sock
+	// Partner is the Sock it was paired with, for a Paired Event.
+	Partner Sock
+}
+
+// History is a log of what became of socks across the baskets they were paired from, so that an
+// orphan can be traced from one load of laundry to the next.
+type History struct {
+	// Events are the recorded events, oldest first.
+	Events []Event
+}
+
+// Record adds what became of every Sock in res, the result of pairing the basket named load.
+func (h *History) Record(load string, res PairingResult) {
+	for _, pair := range res.Pairs {
+		h.Events = append(h.Events,
+			Event{Load: load, Kind: Paired, Sock: pair[0], Partner: pair[1]},
+			Event{Load: load, Kind: Paired, Sock: pair[1], Partner: pair[0]},
+		)
+	}
+	for _, s := range res.Orphans {
+		h.Events = append(h.Events, Event{Load: load, Kind: Orphaned, Sock: s})
+	}
+	for _, s := range res.Unprocessed {
+		h.Events = append(h.Events, Event{Load: load, Kind: Unprocessed, Sock: s})
+	}
+}
+
+// Trace returns the events of the Sock with the given ID, oldest first.
+func (h *History) Trace(id string) []Event {
+	events := make([]Event, 0)
+	for _, e := range h.Events {
+		if e.Sock.ID == id {
+			events = append(events, e)
+		}
+	}
+	return events
+}
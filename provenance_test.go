@@ -0,0 +1,137 @@
+package sock_pair_in_golang_test
+
+import (
+	"reflect"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+func TestAssignIDs(t *testing.T) {
+	socks := sockpair.Socks{
+		{Color: "red", Pattern: "plain", Side: sockpair.Left},
+		{ID: "tagged", Color: "red", Pattern: "plain", Side: sockpair.Right},
+		{Color: "red", Pattern: "plain", Side: sockpair.Left},
+	}
+	got := sockpair.AssignIDs(socks, "load1")
+	want := []string{"load1-1", "tagged", "load1-3"}
+	for i, s := range got {
+		if s.ID != want[i] {
+			t.Errorf("AssignIDs()[%d].ID = %q, want %q", i, s.ID, want[i])
+		}
+	}
+}
+
+func TestAudit(t *testing.T) {
+	redLeft := sockpair.Sock{ID: "1", Color: "red", Pattern: "plain", Side: sockpair.Left}
+	redRight := sockpair.Sock{ID: "2", Color: "red", Pattern: "plain", Side: sockpair.Right}
+	otherRedRight := sockpair.Sock{ID: "3", Color: "red", Pattern: "plain", Side: sockpair.Right}
+	basket := sockpair.Socks{redLeft, redRight, otherRedRight}
+
+	tests := []struct {
+		name    string
+		basket  sockpair.Socks
+		res     sockpair.PairingResult
+		wantErr bool
+	}{
+		{
+			"every sock accounted for",
+			basket,
+			sockpair.PairingResult{Pairs: sockpair.SockPairs{{redLeft, redRight}}, Orphans: sockpair.Socks{otherRedRight}},
+			false,
+		},
+		{
+			"unprocessed socks",
+			basket,
+			sockpair.PairingResult{Unprocessed: basket},
+			false,
+		},
+		{
+			"identical socks paired the other way",
+			basket,
+			sockpair.PairingResult{Pairs: sockpair.SockPairs{{redLeft, otherRedRight}}, Orphans: sockpair.Socks{redRight}},
+			false,
+		},
+		{
+			"missing sock",
+			basket,
+			sockpair.PairingResult{Pairs: sockpair.SockPairs{{redLeft, redRight}}},
+			true,
+		},
+		{
+			"sock accounted for twice",
+			basket,
+			sockpair.PairingResult{Pairs: sockpair.SockPairs{{redLeft, redRight}}, Orphans: sockpair.Socks{otherRedRight, redRight}},
+			true,
+		},
+		{
+			"sock from another basket",
+			basket[:2],
+			sockpair.PairingResult{Pairs: sockpair.SockPairs{{redLeft, otherRedRight}}},
+			true,
+		},
+		{
+			"duplicate ID",
+			sockpair.Socks{redLeft, redLeft},
+			sockpair.PairingResult{Orphans: sockpair.Socks{redLeft, redLeft}},
+			true,
+		},
+		{
+			"socks without IDs",
+			sockpair.Socks{{Color: "red"}, {Color: "red"}},
+			sockpair.PairingResult{Orphans: sockpair.Socks{{Color: "red"}, {Color: "red"}}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := sockpair.Audit(tt.basket, tt.res); (err != nil) != tt.wantErr {
+				t.Errorf("Audit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSockPairingStrategy_PairSocks_keepsIDs(t *testing.T) {
+	basket := sockpair.AssignIDs(sockpair.ShuffleSocksWithRand(append(sockpair.GenerateSocks(
+		[]string{"red", "green", "blue"},
+		[]string{"plain", "striped"},
+		3,
+		false,
+	), sockpair.Sock{Color: "pink", Pattern: "plain", Side: sockpair.Left}), newRand(t)), "sock")
+
+	strategies := []sockpair.SockPairingStrategy{
+		sockpair.RandomPairingStrategy{Rand: newRand(t)},
+		sockpair.SequentialPairingStrategy{},
+		sockpair.SortFirstPairingStrategy{},
+		sockpair.SurfacePairingStrategy{},
+		sockpair.MaximumMatchingPairingStrategy{},
+	}
+	for _, strategy := range strategies {
+		if err := sockpair.Audit(basket, strategy.PairSocks(basket)); err != nil {
+			t.Errorf("Audit(%T.PairSocks()) error = %v", strategy, err)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	lostLeft := sockpair.Sock{ID: "L7", Color: "red", Pattern: "plain", Side: sockpair.Left}
+	foundRight := sockpair.Sock{ID: "R7", Color: "red", Pattern: "plain", Side: sockpair.Right}
+	strategy := sockpair.SurfacePairingStrategy{}
+
+	// the left sock is orphaned in the first load, then pairs with its partner in the second
+	history := sockpair.History{}
+	history.Record("monday", strategy.PairSocks(sockpair.Socks{lostLeft}))
+	history.Record("thursday", strategy.PairSocks(sockpair.Socks{foundRight, lostLeft}))
+
+	want := []sockpair.Event{
+		{Load: "monday", Kind: sockpair.Orphaned, Sock: lostLeft},
+		{Load: "thursday", Kind: sockpair.Paired, Sock: lostLeft, Partner: foundRight},
+	}
+	if got := history.Trace("L7"); !reflect.DeepEqual(got, want) {
+		t.Errorf("History.Trace() = %v, want %v", got, want)
+	}
+	if got := history.Trace("missing"); len(got) != 0 {
+		t.Errorf("History.Trace() = %v, want no events", got)
+	}
+}
@@ -1,20 +1,20 @@
 package sock_pair_in_golang
 
 import (
+	"context"
 	"errors"
 	"math/rand"
-	"sort"
 )
 
-// removeSockFromBasket removes the Sock at the specified index and returns the remaining list.
-// If the index is out of bounds an error is returned.
-func removeSockFromBasket(s Socks, idx int) (Socks, error) {
+// removeSockFromBasket removes the Sock, or other item, at the specified index and returns the
+// remaining list. If the index is out of bounds an error is returned.
+func removeSockFromBasket[S ~[]T, T any](s S, idx int) (S, error) {
 	if idx >= len(s) || idx < 0 {
 		return s, errors.New("invalid index")
 	}
 
 	if len(s) == 1 && idx == 0 {
-		return make([]Sock, 0), nil
+		return make(S, 0), nil
 	}
 
 	if idx+1 <= len(s)-1 {
@@ -24,218 +24,159 @@ func removeSockFromBasket(s Socks, idx int) (Socks, error) {
 	return s[:idx], nil
 }
 
-// orderSockPair returns the pair of Sock ordered as left, right.
+// prepareBasket returns the items a strategy is free to reorder and overwrite: the caller's basket
+// itself when inPlace is set, otherwise a copy of it.
+func prepareBasket[T any](items []T, inPlace bool) []T {
+	if inPlace {
+		return items
+	}
+	return append(make([]T, 0, len(items)), items...)
+}
+
+// orderSockPair returns the pair of Sock ordered as left, right. A Sock that fits either foot
+// takes whichever place its partner leaves free; two of them are left in the order given.
 func orderSockPair(s1, s2 Sock) (Sock, Sock) {
-	if s1.IsLeft {
-		return s1, s2
+	if s2.Side == Left || s1.Side == Right {
+		return s2, s1
 	}
-	return s2, s1
+	return s1, s2
+}
+
+// SockRules returns the Rules for pairing socks: socks of the same color and pattern made for
+// opposite feet make a pair, as do socks that fit either foot.
+func SockRules() Rules[Sock, Sock] {
+	return MatcherRules(ExactMatcher{})
+}
+
+// newPairingResult converts the Result of pairing socks with the generic strategies to a PairingResult.
+func newPairingResult(res Result[Sock]) PairingResult {
+	pairs := make(SockPairs, len(res.Pairs))
+	for i, pair := range res.Pairs {
+		pairs[i] = pair
+	}
+
+	return PairingResult{Pairs: pairs, Orphans: res.Orphans, Unprocessed: res.Unprocessed, Stats: res.Stats}
 }
 
+// SockPairingStrategy is implemented by any approach to pairing the socks in a basket.
+//
+// PairSocks must account for every Sock it is given exactly once, either as one half of a
+// pair or as an orphan, and must leave freshSocks untouched unless the caller has explicitly
+// configured the strategy to work in place. Each pair is ordered left, right, but the pairs and
+// orphans themselves may be returned in any order. Strategies should fill in whichever of the
+// PairingResult.Stats counters apply to them and leave the rest at zero. Use the
+// sockpairtest package to check that an implementation satisfies this contract.
 type SockPairingStrategy interface {
-	pairSocks(freshSocks Socks) (SockPairs, Socks)
+	PairSocks(freshSocks Socks) PairingResult
 }
 
-// RandomPairingStrategy is the process of grabbing the first Sock in the basket, then
-// drawing a second random Sock from the basket for comparison.
-type RandomPairingStrategy struct{}
+// Pair pairs the socks in the basket using the given strategy.
+func Pair(strategy SockPairingStrategy, socks Socks) PairingResult {
+	return strategy.PairSocks(socks)
+}
 
-func (s RandomPairingStrategy) pairSocks(freshSocks Socks) (SockPairs, Socks) {
-	pairedSocks := make(SockPairs, 0)
-	orphanedSocks := make(Socks, 0)
+// ContextPairingStrategy is implemented by strategies that can be stopped part way through a basket.
+//
+// PairSocksContext must stop promptly once ctx is done, returning ctx.Err() along with the pairs
+// and orphans found so far. Every Sock it has not finished with belongs in
+// PairingResult.Unprocessed, so that the result still accounts for the whole basket.
+type ContextPairingStrategy interface {
+	SockPairingStrategy
+	PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error)
+}
 
-	// check for empty basket
-	if len(freshSocks) == 0 {
-		return pairedSocks, orphanedSocks
+// PairContext pairs the socks in the basket using the given strategy, giving up once ctx is done.
+// Strategies that do not implement ContextPairingStrategy cannot be stopped once they have
+// started, so ctx is only checked before they are run.
+func PairContext(ctx context.Context, strategy SockPairingStrategy, socks Socks) (PairingResult, error) {
+	if s, ok := strategy.(ContextPairingStrategy); ok {
+		return s.PairSocksContext(ctx, socks)
 	}
 
-	// check for single item basket
-	if len(freshSocks) == 1 {
-		return pairedSocks, append(orphanedSocks, freshSocks[0])
+	if err := ctx.Err(); err != nil {
+		unprocessedSocks := append(Socks{}, socks...)
+		return PairingResult{Pairs: make(SockPairs, 0), Orphans: make(Socks, 0), Unprocessed: unprocessedSocks}, err
 	}
+	return strategy.PairSocks(socks), nil
+}
 
-	sockToPair := freshSocks[0]
-	freshSocks = freshSocks[1:]
-	reassignAndCheckForOrphans := false
-
-	comparisonCount := 0
-	for len(freshSocks) > 0 {
-		// generate a random index
-		randomIdx := rand.Intn(len(freshSocks))
-		foundSock := freshSocks[randomIdx]
-
-		if sockToPair.IsMatchingPair(foundSock) {
-			// add our socks to the pairedSocks
-			leftSock, rightSock := orderSockPair(sockToPair, foundSock)
-			pairedSocks = append(pairedSocks, Socks{leftSock, rightSock})
-
-			// remove the matched sock
-			if res, err := removeSockFromBasket(freshSocks, randomIdx); err == nil {
-				freshSocks = res
-			}
-
-			reassignAndCheckForOrphans = true
-		} else if comparisonCount > len(freshSocks)*len(freshSocks) {
-			orphanedSocks = append(orphanedSocks, sockToPair)
-			reassignAndCheckForOrphans = true
-		}
-
-		comparisonCount++
-
-		if reassignAndCheckForOrphans {
-			if len(freshSocks) > 1 {
-				// assign a new sockToPair to compare against
-				sockToPair = freshSocks[0]
-				// remove the new sockToPair from the unpairedSocks
-				if res, err := removeSockFromBasket(freshSocks, 0); err == nil {
-					freshSocks = res
-				}
-				// reset the index, so we can compare our sockToPair against all the unpairedSocks
-				reassignAndCheckForOrphans = false
-				// reset comparisonCount
-				comparisonCount = 0
-			} else {
-				// last sock is an orphan
-				orphanedSocks = append(orphanedSocks, freshSocks...)
-				break
-			}
-		}
-	}
+// RandomPairingStrategy is the process of grabbing the first Sock in the basket, then
+// drawing a second random Sock from the basket for comparison.
+type RandomPairingStrategy struct {
+	// Rand is the source of the random draws. Seed it to make a run reproducible; a nil Rand uses
+	// the shared source from the math/rand package. A *rand.Rand is not safe for concurrent use,
+	// so a strategy with a Rand must not be shared between goroutines.
+	Rand *rand.Rand
+	// Matcher decides which socks pair. A nil Matcher uses ExactMatcher.
+	Matcher Matcher
+	// InPlace pairs the socks within the caller's basket rather than a copy of it, saving an
+	// allocation but leaving the basket in an unspecified order.
+	InPlace bool
+}
 
-	return pairedSocks, orphanedSocks
+func (s RandomPairingStrategy) PairSocks(freshSocks Socks) PairingResult {
+	res, _ := s.PairSocksContext(context.Background(), freshSocks)
+	return res
+}
+
+func (s RandomPairingStrategy) PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error) {
+	res, err := PairRandom(ctx, freshSocks, MatcherRules(s.Matcher), PairingOptions{Rand: s.Rand, InPlace: s.InPlace})
+	return newPairingResult(res), err
 }
 
 // SequentialPairingStrategy is the process of grabbing the first Sock in the basket, then
 // comparing it to each subsequent Sock from the basket for comparison.
-type SequentialPairingStrategy struct{}
-
-func (s SequentialPairingStrategy) pairSocks(freshSocks Socks) (SockPairs, Socks) {
-	pairedSocks := make(SockPairs, 0)
-	orphanedSocks := make(Socks, 0)
-
-	// check for empty basket
-	if len(freshSocks) == 0 {
-		return pairedSocks, orphanedSocks
-	}
-
-	// check for single item basket
-	if len(freshSocks) == 1 {
-		return pairedSocks, append(orphanedSocks, freshSocks[0])
-	}
+type SequentialPairingStrategy struct {
+	// Matcher decides which socks pair. A nil Matcher uses ExactMatcher.
+	Matcher Matcher
+	// InPlace pairs the socks within the caller's basket rather than a copy of it, saving an
+	// allocation but leaving the basket in an unspecified order.
+	InPlace bool
+}
 
-	sockToPair := freshSocks[0]
-	freshSocks = freshSocks[1:]
-	reassignAndCheckForOrphans := false
-
-	i := 0
-	for len(freshSocks) > 0 {
-		if sockToPair.IsMatchingPair(freshSocks[i]) {
-			// add our socks to the pairedSocks
-			leftSock, rightSock := orderSockPair(sockToPair, freshSocks[i])
-			pairedSocks = append(pairedSocks, Socks{leftSock, rightSock})
-
-			// remove the matched sock
-			if res, err := removeSockFromBasket(freshSocks, i); err == nil {
-				freshSocks = res
-			}
-
-			reassignAndCheckForOrphans = true
-		} else {
-			i++
-
-			// check if we've encountered an orphaned sock that is not at the bottom of the basket
-			if i >= len(freshSocks) {
-				// add sockToPair to orphanedSocks
-				orphanedSocks = append(orphanedSocks, sockToPair)
-				reassignAndCheckForOrphans = true
-			}
-		}
-
-		if reassignAndCheckForOrphans {
-			if len(freshSocks) > 1 {
-				// assign a new sockToPair to compare against
-				sockToPair = freshSocks[0]
-				// remove the new sockToPair from the unpairedSocks
-				if res, err := removeSockFromBasket(freshSocks, 0); err == nil {
-					freshSocks = res
-				}
-				// reset the index, so we can compare our sockToPair against all the unpairedSocks
-				i = 0
-				reassignAndCheckForOrphans = false
-			} else {
-				// last sock is an orphan
-				orphanedSocks = append(orphanedSocks, freshSocks...)
-				break
-			}
-		}
-	}
+func (s SequentialPairingStrategy) PairSocks(freshSocks Socks) PairingResult {
+	res, _ := s.PairSocksContext(context.Background(), freshSocks)
+	return res
+}
 
-	return pairedSocks, orphanedSocks
+func (s SequentialPairingStrategy) PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error) {
+	res, err := PairSequential(ctx, freshSocks, MatcherRules(s.Matcher), PairingOptions{InPlace: s.InPlace})
+	return newPairingResult(res), err
 }
 
 // SortFirstPairingStrategy is the process of sorting all the socks in the basket by color, then
-// comparing each nth and nth+1 Sock in the basket.
-type SortFirstPairingStrategy struct{}
-
-func (s SortFirstPairingStrategy) pairSocks(freshSocks Socks) (SockPairs, Socks) {
-	pairedSocks := make(SockPairs, 0)
-	orphanedSocks := make(Socks, 0)
-
-	sort.Sort(freshSocks)
-
-	i := 0
-	for len(freshSocks) > 0 {
-		if i+1 < len(freshSocks) {
-			if freshSocks[i].IsMatchingPair(freshSocks[i+1]) {
-				leftSock, rightSock := orderSockPair(freshSocks[i], freshSocks[i+1])
-				pairedSocks = append(pairedSocks, Socks{leftSock, rightSock})
-				i++
-			} else {
-				orphanedSocks = append(orphanedSocks, freshSocks[i])
-			}
-			// update socks
-			freshSocks = freshSocks[i+1:]
-			i = 0
-		} else {
-			orphanedSocks = append(orphanedSocks, freshSocks[i])
-			freshSocks = freshSocks[i+1:]
-		}
-	}
+// pairing each Sock with an unpaired Sock of the same kind sorted before it.
+type SortFirstPairingStrategy struct {
+	// Matcher decides which socks pair. A nil Matcher uses ExactMatcher.
+	Matcher Matcher
+	// InPlace pairs the socks within the caller's basket rather than a copy of it, saving an
+	// allocation but leaving the basket in an unspecified order.
+	InPlace bool
+}
+
+func (s SortFirstPairingStrategy) PairSocks(freshSocks Socks) PairingResult {
+	res, _ := s.PairSocksContext(context.Background(), freshSocks)
+	return res
+}
 
-	return pairedSocks, orphanedSocks
+func (s SortFirstPairingStrategy) PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error) {
+	res, err := PairSortFirst(ctx, freshSocks, MatcherRules(s.Matcher), PairingOptions{InPlace: s.InPlace})
+	return newPairingResult(res), err
 }
 
 // SurfacePairingStrategy is the process of placing each Sock on a surface and checking for matches
-// each time a new Sock is pulled from the basket.
-type SurfacePairingStrategy struct{}
-
-func (s SurfacePairingStrategy) pairSocks(freshSocks Socks) (SockPairs, Socks) {
-	pairedSocks := make(SockPairs, 0)
-	orphanedSocks := make(Socks, 0)
-	surface := make(map[Sock]Socks)
-
-	for _, sock := range freshSocks {
-		matchingSock := Sock{sock.Color, sock.Pattern, !sock.IsLeft}
-		// check if matching sock already exists on the surface
-		if surface[matchingSock] != nil && len(surface[matchingSock]) > 0 {
-			leftSock, rightSock := orderSockPair(sock, matchingSock)
-			pairedSocks = append(pairedSocks, Socks{leftSock, rightSock})
-			// remove the matching sock from the surface
-			if res, err := removeSockFromBasket(surface[matchingSock], 0); err == nil {
-				surface[matchingSock] = res
-			}
-		} else {
-			if surface[sock] == nil {
-				surface[sock] = make(Socks, 0)
-			}
-			surface[sock] = append(surface[sock], sock)
-		}
-	}
+// each time a new Sock is pulled from the basket. It never modifies the basket.
+type SurfacePairingStrategy struct {
+	// Matcher decides which socks pair. A nil Matcher uses ExactMatcher.
+	Matcher Matcher
+}
 
-	// collect remaining orphaned socks
-	for _, socks := range surface {
-		orphanedSocks = append(orphanedSocks, socks...)
-	}
+func (s SurfacePairingStrategy) PairSocks(freshSocks Socks) PairingResult {
+	res, _ := s.PairSocksContext(context.Background(), freshSocks)
+	return res
+}
 
-	return pairedSocks, orphanedSocks
+func (s SurfacePairingStrategy) PairSocksContext(ctx context.Context, freshSocks Socks) (PairingResult, error) {
+	res, err := PairSurface(ctx, freshSocks, MatcherRules(s.Matcher), PairingOptions{})
+	return newPairingResult(res), err
 }
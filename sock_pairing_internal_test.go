@@ -0,0 +1,118 @@
+package sock_pair_in_golang
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_removeSockFromBasket(t *testing.T) {
+	const basket = "red/plain/L, red/plain/R, green/plain/R, blue/plain/R, blue/plain/L, green/plain/L"
+	tests := []struct {
+		name       string
+		idx        int
+		freshSocks Socks
+		want       Socks
+		wantErr    bool
+	}{
+		{
+			"slice from 0:",
+			0,
+			MustParseSocks(basket),
+			MustParseSocks("red/plain/R, green/plain/R, blue/plain/R, blue/plain/L, green/plain/L"),
+			false,
+		},
+		{
+			"slice from 0:2,3:",
+			2,
+			MustParseSocks(basket),
+			MustParseSocks("red/plain/L, red/plain/R, blue/plain/R, blue/plain/L, green/plain/L"),
+			false,
+		},
+		{
+			"slice from 0:5",
+			5,
+			MustParseSocks(basket),
+			MustParseSocks("red/plain/L, red/plain/R, green/plain/R, blue/plain/R, blue/plain/L"),
+			false,
+		},
+		{
+			"slice from 0:4, 5:",
+			4,
+			MustParseSocks(basket),
+			MustParseSocks("red/plain/L, red/plain/R, green/plain/R, blue/plain/R, green/plain/L"),
+			false,
+		},
+		{
+			"invalid index",
+			15,
+			MustParseSocks(basket),
+			MustParseSocks(basket),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := removeSockFromBasket(tt.freshSocks, tt.idx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("removeSockFromBasket() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("removeSockFromBasket() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_orderSockPair(t *testing.T) {
+	tests := []struct {
+		name string
+		// pair is the two socks to order, and want them in order.
+		pair string
+		want string
+	}{
+		{"left, right", "red/plain/L + red/plain/R", "red/plain/L + red/plain/R"},
+		{"right, left", "red/plain/R + red/plain/L", "red/plain/L + red/plain/R"},
+		{"left, left", "red/plain/L + red/plain/L", "red/plain/L + red/plain/L"},
+		{"either, left", "red/plain/E + red/plain/L", "red/plain/L + red/plain/E"},
+		{"right, either", "red/plain/R + red/plain/E", "red/plain/E + red/plain/R"},
+		{"either, either", "red/plain/E + blue/plain/E", "red/plain/E + blue/plain/E"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair, want := mustParseSockPair(tt.pair), mustParseSockPair(tt.want)
+			gotLeft, gotRight := orderSockPair(pair[0], pair[1])
+			if !reflect.DeepEqual(gotLeft, want[0]) {
+				t.Errorf("orderSockPair() gotLeft = %v, want %v", gotLeft, want[0])
+			}
+			if !reflect.DeepEqual(gotRight, want[1]) {
+				t.Errorf("orderSockPair() gotRight = %v, want %v", gotRight, want[1])
+			}
+		})
+	}
+}
+
+// mustParseSockPair parses a single pair written in the notation, panicking if it cannot.
+func mustParseSockPair(text string) SockPair {
+	pairs, err := ParseSockPairs(text)
+	if err != nil || len(pairs) != 1 {
+		panic("mustParseSockPair(" + text + "): want a single pair")
+	}
+	return pairs[0]
+}
+
+func Test_prepareBasket(t *testing.T) {
+	freshSocks := MustParseSocks("red/plain/L, red/plain/R")
+
+	copied := prepareBasket(freshSocks, false)
+	copied[0] = Sock{Color: "blue", Pattern: "plain", Side: Left}
+	if freshSocks[0] != (Sock{Color: "red", Pattern: "plain", Side: Left}) {
+		t.Errorf("prepareBasket(inPlace = false) shares the caller's basket")
+	}
+
+	inPlace := prepareBasket(freshSocks, true)
+	inPlace[0] = Sock{Color: "blue", Pattern: "plain", Side: Left}
+	if freshSocks[0] != (Sock{Color: "blue", Pattern: "plain", Side: Left}) {
+		t.Errorf("prepareBasket(inPlace = true) copied the caller's basket")
+	}
+}
@@ -1,494 +1,540 @@
-package sock_pair_in_golang
+package sock_pair_in_golang_test
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
 	"reflect"
-	"sort"
 	"testing"
+	"time"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/sockpairtest"
 )
 
-type testCase struct {
-	name        string
-	freshSocks  Socks
-	wantPairs   SockPairs
-	wantOrphans Socks
+var seed = flag.Int64("seed", 1, "seed for shuffling baskets and random pairing, 0 picks one from the clock")
+
+// newRand returns a Rand seeded from the -seed flag, logging the seed so the run can be reproduced
+// with go test -seed.
+func newRand(tb testing.TB) *rand.Rand {
+	s := *seed
+	if s == 0 {
+		s = time.Now().UnixNano()
+	}
+	tb.Logf("seed: %d", s)
+	return rand.New(rand.NewSource(s))
 }
 
-func getTestCases() []testCase {
-	return []testCase{
-		{
-			"3 matching pairs",
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"green", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", false},
-			},
-			SockPairs{
-				Socks{Sock{"blue", "plain", true}, Sock{"blue", "plain", false}},
-				Socks{Sock{"green", "plain", true}, Sock{"green", "plain", false}},
-				Socks{Sock{"red", "plain", true}, Sock{"red", "plain", false}},
-			},
-			make([]Sock, 0),
-		},
-		{
-			"3 matching pairs, 1 orphaned sock",
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"green", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", false},
-				Sock{"pink", "plain", true},
-			},
-			SockPairs{
-				Socks{Sock{"blue", "plain", true}, Sock{"blue", "plain", false}},
-				Socks{Sock{"green", "plain", true}, Sock{"green", "plain", false}},
-				Socks{Sock{"red", "plain", true}, Sock{"red", "plain", false}},
-			},
-			Socks{{"pink", "plain", true}},
-		},
-		{
-			"all orphaned freshSocks",
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"green", "plain", true},
-				Sock{"red", "plain", true},
-				Sock{"blue", "plain", true},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-				Sock{"pink", "plain", true},
-			},
-			make(SockPairs, 0),
-			Socks{
-				Sock{"blue", "plain", true},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-				Sock{"green", "plain", true},
-				Sock{"pink", "plain", true},
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", true},
-			},
-		},
-		{
-			"no socks",
-			make(Socks, 0),
-			make(SockPairs, 0),
-			make(Socks, 0),
-		},
-		{
-			"single sock",
-			Socks{Sock{"pink", "plain", true}},
-			make(SockPairs, 0),
-			Socks{Sock{"pink", "plain", true}},
-		},
+func TestPair(t *testing.T) {
+	for _, tt := range sockpairtest.TestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			got := sockpair.Pair(sockpair.SurfacePairingStrategy{}, tt.FreshSocks)
+			if len(got.Pairs) != len(tt.WantPairs) {
+				t.Errorf("Pair() pairs = %v, want %v", got.Pairs, tt.WantPairs)
+			}
+			if len(got.Orphans) != len(tt.WantOrphans) {
+				t.Errorf("Pair() orphans = %v, want %v", got.Orphans, tt.WantOrphans)
+			}
+		})
 	}
 }
 
-func Test_removeSockFromBasket(t *testing.T) {
+func TestPairingStats(t *testing.T) {
 	tests := []struct {
-		name       string
-		idx        int
-		freshSocks Socks
-		want       Socks
-		wantErr    bool
+		name     string
+		strategy sockpair.SockPairingStrategy
+		want     sockpair.PairingStats
 	}{
 		{
-			"slice from 0:",
-			0,
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-			},
-			Socks{
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-			},
-			false,
-		},
-		{
-			"slice from 0:2,3:",
-			2,
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-			},
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-			},
-			false,
+			"sequential",
+			sockpair.SequentialPairingStrategy{},
+			sockpair.PairingStats{Comparisons: 6, Draws: 9, Removals: 5, Steps: 6},
 		},
 		{
-			"slice from 0:5",
-			5,
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-			},
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-			},
-			false,
+			"sort first",
+			sockpair.SortFirstPairingStrategy{},
+			sockpair.PairingStats{Comparisons: 3, Draws: 6, Steps: 6},
 		},
 		{
-			"slice from 0:4, 5:",
-			4,
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-			},
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"green", "plain", true},
-			},
-			false,
+			"surface",
+			sockpair.SurfacePairingStrategy{},
+			sockpair.PairingStats{Comparisons: 3, Draws: 6, Removals: 3, SurfacePeak: 2, Placements: 3, Scans: 6, Steps: 6},
 		},
 		{
-			"invalid index",
-			15,
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-			},
-			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"green", "plain", false},
-				Sock{"blue", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-			},
-			true,
+			"maximum matching",
+			sockpair.MaximumMatchingPairingStrategy{},
+			sockpair.PairingStats{Comparisons: 3, Draws: 6, SurfacePeak: 6, Placements: 6, Steps: 3},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := removeSockFromBasket(tt.freshSocks, tt.idx)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("removeSockFromBasket() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			got := tt.strategy.PairSocks(sockpairtest.TestCases()[0].FreshSocks).Stats
+			// the number of comparisons made by sort.Sort is an implementation detail of the sort package
+			if _, sorts := tt.strategy.(sockpair.SortFirstPairingStrategy); sorts {
+				if got.SortComparisons == 0 {
+					t.Errorf("%T.PairSocks() made no sort comparisons", tt.strategy)
+				}
+				got.SortComparisons = 0
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("removeSockFromBasket() got = %v, want %v", got, tt.want)
+			if got != tt.want {
+				t.Errorf("%T.PairSocks() stats = %+v, want %+v", tt.strategy, got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_orderSockPair(t *testing.T) {
-	tests := []struct {
-		name      string
-		s1        Sock
-		s2        Sock
-		wantLeft  Sock
-		wantRight Sock
-	}{
-		{
-			"left, right",
-			Sock{"red", "plain", true},
-			Sock{"red", "plain", false},
-			Sock{"red", "plain", true},
-			Sock{"red", "plain", false},
-		},
-		{
-			"right, left",
-			Sock{"red", "plain", false},
-			Sock{"red", "plain", true},
-			Sock{"red", "plain", true},
-			Sock{"red", "plain", false},
-		},
-		{
-			"left, left",
-			Sock{"red", "plain", true},
-			Sock{"red", "plain", true},
-			Sock{"red", "plain", true},
-			Sock{"red", "plain", true},
-		},
+func TestPairingStats_oneKind(t *testing.T) {
+	// socks that all look alike are paired by side, without comparing each against the others
+	baskets := map[string]sockpair.Socks{
+		"pairs": sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks([]string{"black"}, []string{"plain"}, 1000, false), newRand(t)),
+		"lefts": sockpair.GenerateSocks([]string{"black"}, []string{"plain"}, 2000, true),
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			gotLeft, gotRight := orderSockPair(tt.s1, tt.s2)
-			if !reflect.DeepEqual(gotLeft, tt.wantLeft) {
-				t.Errorf("orderSockPair() gotLeft = %v, want %v", gotLeft, tt.wantLeft)
-			}
-			if !reflect.DeepEqual(gotRight, tt.wantRight) {
-				t.Errorf("orderSockPair() gotRight = %v, want %v", gotRight, tt.wantRight)
+	strategies := map[string]sockpair.SockPairingStrategy{
+		"sort first": sockpair.SortFirstPairingStrategy{},
+		"surface":    sockpair.SurfacePairingStrategy{},
+	}
+	for strategyName, strategy := range strategies {
+		for basketName, basket := range baskets {
+			t.Run(strategyName+"/"+basketName, func(t *testing.T) {
+				got := strategy.PairSocks(basket).Stats
+				if got.Comparisons > len(basket)/2 {
+					t.Errorf("PairSocks() made %d comparisons pairing %d socks, want at most %d", got.Comparisons, len(basket), len(basket)/2)
+				}
+			})
+		}
+	}
+}
+
+func TestSockPairingStrategy_PairSocks_leavesBasketUntouched(t *testing.T) {
+	strategies := []sockpair.SockPairingStrategy{
+		sockpair.RandomPairingStrategy{Rand: newRand(t)},
+		sockpair.SequentialPairingStrategy{},
+		sockpair.SortFirstPairingStrategy{},
+		sockpair.SurfacePairingStrategy{},
+	}
+	for _, strategy := range strategies {
+		t.Run(fmt.Sprintf("%T", strategy), func(t *testing.T) {
+			basket := sockpair.ShuffleSocksWithRand(append(sockpair.GenerateSocks(
+				[]string{"red", "green", "blue"},
+				[]string{"plain", "striped"},
+				3,
+				false,
+			), sockpair.Sock{Color: "pink", Pattern: "plain", Side: sockpair.Left}), newRand(t))
+			want := append(sockpair.Socks{}, basket...)
+
+			strategy.PairSocks(basket)
+			if !reflect.DeepEqual(basket, want) {
+				t.Errorf("%T.PairSocks() modified basket to %v, want %v", strategy, basket, want)
 			}
 		})
 	}
 }
 
-func TestRandomPairingStrategy_pairSocks(t *testing.T) {
-	for _, tt := range getTestCases() {
-		t.Run(tt.name, func(t *testing.T) {
-			strategy := RandomPairingStrategy{}
-			gotPairs, gotOrphans := strategy.pairSocks(tt.freshSocks)
-			// sort the returned values, so they can be compared to our shared test values
-			sort.Sort(gotPairs)
-			sort.Sort(gotOrphans)
-
-			if !reflect.DeepEqual(gotPairs, tt.wantPairs) {
-				t.Errorf("RandomPairingStrategy.pairSocks() pairs = %v, want %v", gotPairs, tt.wantPairs)
-			}
+func TestSockPairingStrategy_PairSocks_inPlace(t *testing.T) {
+	strategies := []sockpair.SockPairingStrategy{
+		sockpair.RandomPairingStrategy{Rand: newRand(t), InPlace: true},
+		sockpair.SequentialPairingStrategy{InPlace: true},
+		sockpair.SortFirstPairingStrategy{InPlace: true},
+	}
+	for _, strategy := range strategies {
+		for _, tt := range sockpairtest.TestCases() {
+			t.Run(fmt.Sprintf("%T/%s", strategy, tt.Name), func(t *testing.T) {
+				got := strategy.PairSocks(tt.FreshSocks)
+				if len(got.Pairs) != len(tt.WantPairs) {
+					t.Errorf("%T.PairSocks() pairs = %v, want %v", strategy, got.Pairs, tt.WantPairs)
+				}
+				if len(got.Orphans) != len(tt.WantOrphans) {
+					t.Errorf("%T.PairSocks() orphans = %v, want %v", strategy, got.Orphans, tt.WantOrphans)
+				}
+			})
+		}
+	}
+}
+
+func TestSockPairingStrategy_PairSocks_attributes(t *testing.T) {
+	// child and adult socks of the same color, belonging to different people
+	basket := sockpair.ShuffleSocksWithRand(sockpair.GenerateVariedSocks(sockpair.Variety{
+		Colors:   []string{"red", "blue"},
+		Patterns: []string{"plain"},
+		Sizes:    []string{"child", "adult"},
+		Owners:   []string{"sam", "alex"},
+	}, 1, false), newRand(t))
 
-			if !reflect.DeepEqual(gotOrphans, tt.wantOrphans) {
-				t.Errorf("RandomPairingStrategy.pairSocks() orphans = %v, want %v", gotOrphans, tt.wantOrphans)
+	strategies := []sockpair.SockPairingStrategy{
+		sockpair.RandomPairingStrategy{Rand: newRand(t)},
+		sockpair.SequentialPairingStrategy{},
+		sockpair.SortFirstPairingStrategy{},
+		sockpair.SurfacePairingStrategy{},
+		sockpair.MaximumMatchingPairingStrategy{},
+	}
+	for _, strategy := range strategies {
+		got := strategy.PairSocks(basket)
+		if len(got.Pairs) != len(basket)/2 || len(got.Orphans) != 0 {
+			t.Errorf("%T.PairSocks() = %v, %v, want %d pairs", strategy, got.Pairs, got.Orphans, len(basket)/2)
+		}
+		for _, pair := range got.Pairs {
+			if pair[0].Size != pair[1].Size || pair[0].Owner != pair[1].Owner {
+				t.Errorf("%T.PairSocks() paired %v", strategy, pair)
 			}
-		})
+		}
 	}
 }
 
-func BenchmarkRandomPairingStrategy_pairSocks_noOrphans(b *testing.B) {
+func TestPairContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	basket := sockpairtest.TestCases()[0].FreshSocks
+
+	t.Run("ContextPairingStrategy", func(t *testing.T) {
+		got, err := sockpair.PairContext(ctx, sockpair.SequentialPairingStrategy{}, basket)
+		if err != context.Canceled {
+			t.Errorf("PairContext() error = %v, want %v", err, context.Canceled)
+		}
+		if len(got.Pairs) != 0 || len(got.Unprocessed) != len(basket) {
+			t.Errorf("PairContext() = %+v, want every sock unprocessed", got)
+		}
+	})
+
+	t.Run("SockPairingStrategy", func(t *testing.T) {
+		// hide PairSocksContext, so the strategy can only be checked before it starts
+		strategy := struct{ sockpair.SockPairingStrategy }{sockpair.SequentialPairingStrategy{}}
+		got, err := sockpair.PairContext(ctx, strategy, basket)
+		if err != context.Canceled {
+			t.Errorf("PairContext() error = %v, want %v", err, context.Canceled)
+		}
+		if !reflect.DeepEqual(got.Unprocessed, basket) {
+			t.Errorf("PairContext() unprocessed = %v, want %v", got.Unprocessed, basket)
+		}
+
+		got, err = sockpair.PairContext(context.Background(), strategy, basket)
+		if err != nil || len(got.Pairs) != 3 {
+			t.Errorf("PairContext() = %+v, %v, want 3 pairs", got, err)
+		}
+	})
+}
+
+func TestRandomPairingStrategy_PairSocksContext_deadline(t *testing.T) {
+	// pairing this many orphans at random takes seconds
+	basket := sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		10,
+		true,
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	got, err := sockpair.RandomPairingStrategy{Rand: newRand(t)}.PairSocksContext(ctx, basket)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RandomPairingStrategy.PairSocksContext() took %v to stop", elapsed)
+	}
+	if err != context.DeadlineExceeded {
+		t.Errorf("RandomPairingStrategy.PairSocksContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if len(got.Unprocessed) == 0 {
+		t.Errorf("RandomPairingStrategy.PairSocksContext() left no socks unprocessed")
+	}
+	if total := len(got.Orphans) + len(got.Unprocessed); total != len(basket) {
+		t.Errorf("RandomPairingStrategy.PairSocksContext() accounted for %d socks, want %d", total, len(basket))
+	}
+}
+
+func TestRandomPairingStrategy_PairSocks(t *testing.T) {
+	sockpairtest.TestStrategy(t, sockpair.RandomPairingStrategy{Rand: newRand(t)})
+}
+
+func TestRandomPairingStrategy_PairSocks_reproducible(t *testing.T) {
+	s := time.Now().UnixNano()
+	pair := func() sockpair.PairingResult {
+		r := rand.New(rand.NewSource(s))
+		basket := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+			[]string{"red", "green", "blue"},
+			[]string{"plain", "striped"},
+			3,
+			false,
+		), r)
+		return sockpair.RandomPairingStrategy{Rand: r}.PairSocks(basket)
+	}
+	first, second := pair(), pair()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("RandomPairingStrategy.PairSocks() with seed %d = %v, then %v", s, first, second)
+	}
+}
+
+func BenchmarkRandomPairingStrategy_PairSocks_noOrphans(b *testing.B) {
 	b.Skip("Skip in short mode")
-	strategy := RandomPairingStrategy{}
-	testSocks := ShuffleSocks(GenerateSocks(
+	strategy := sockpair.RandomPairingStrategy{Rand: newRand(b)}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		false,
-	))
+	), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkRandomPairingStrategy_pairSocks_singleOrphan(b *testing.B) {
+func BenchmarkRandomPairingStrategy_PairSocks_singleOrphan(b *testing.B) {
 	b.Skip("Skip in short mode")
-	strategy := RandomPairingStrategy{}
-	testSocks := ShuffleSocks(append(GenerateSocks(
+	strategy := sockpair.RandomPairingStrategy{Rand: newRand(b)}
+	testSocks := sockpair.ShuffleSocksWithRand(append(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		false,
-	), Sock{"pink", "plain", true}))
+	), sockpair.Sock{Color: "pink", Pattern: "plain", Side: sockpair.Left}), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkRandomPairingStrategy_pairSocks_allOrphans(b *testing.B) {
+func BenchmarkRandomPairingStrategy_PairSocks_allOrphans(b *testing.B) {
 	b.Skip("Skip in short mode")
-	strategy := RandomPairingStrategy{}
-	testSocks := ShuffleSocks(GenerateSocks(
+	strategy := sockpair.RandomPairingStrategy{Rand: newRand(b)}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		true,
-	))
+	), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func TestSequentialPairingStrategy_pairSocks(t *testing.T) {
-	for _, tt := range getTestCases() {
-		t.Run(tt.name, func(t *testing.T) {
-			strategy := SequentialPairingStrategy{}
-			gotPairs, gotOrphans := strategy.pairSocks(tt.freshSocks)
-			// sort the returned values, so they can be compared to our shared test values
-			sort.Sort(gotPairs)
-			sort.Sort(gotOrphans)
-
-			if !reflect.DeepEqual(gotPairs, tt.wantPairs) {
-				t.Errorf("SequentialPairingStrategy.pairSocks() pairs = %v, want %v", gotPairs, tt.wantPairs)
-			}
+func TestSequentialPairingStrategy_PairSocks(t *testing.T) {
+	sockpairtest.TestStrategy(t, sockpair.SequentialPairingStrategy{})
+}
 
-			if !reflect.DeepEqual(gotOrphans, tt.wantOrphans) {
-				t.Errorf("SequentialPairingStrategy.pairSocks() orphans = %v, want %v", gotOrphans, tt.wantOrphans)
-			}
-		})
+func BenchmarkSequentialPairingStrategy_PairSocks_noOrphans(b *testing.B) {
+	strategy := sockpair.SequentialPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		10,
+		false,
+	), newRand(b))
+	for i := 0; i < b.N; i++ {
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkSequentialPairingStrategy_pairSocks_noOrphans(b *testing.B) {
-	strategy := SequentialPairingStrategy{}
-	testSocks := ShuffleSocks(GenerateSocks(
+func BenchmarkSequentialPairingStrategy_PairSocks_singleOrphan(b *testing.B) {
+	strategy := sockpair.SequentialPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(append(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		false,
-	))
+	), sockpair.Sock{Color: "pink", Pattern: "plain", Side: sockpair.Left}), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkSequentialPairingStrategy_pairSocks_singleOrphan(b *testing.B) {
-	strategy := SequentialPairingStrategy{}
-	testSocks := ShuffleSocks(append(GenerateSocks(
+func BenchmarkSequentialPairingStrategy_PairSocks_allOrphans(b *testing.B) {
+	strategy := sockpair.SequentialPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		10,
+		true,
+	), newRand(b))
+	for i := 0; i < b.N; i++ {
+		strategy.PairSocks(testSocks)
+	}
+}
+
+func TestSortFirstPairingStrategy_PairSocks(t *testing.T) {
+	sockpairtest.TestStrategy(t, sockpair.SortFirstPairingStrategy{})
+}
+
+func BenchmarkSortFirstPairingStrategy_PairSocks_noOrphans(b *testing.B) {
+	strategy := sockpair.SortFirstPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		false,
-	), Sock{"pink", "plain", true}))
+	), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkSequentialPairingStrategy_pairSocks_allOrphans(b *testing.B) {
-	strategy := SequentialPairingStrategy{}
-	testSocks := ShuffleSocks(GenerateSocks(
+func BenchmarkSortFirstPairingStrategy_PairSocks_singleOrphan(b *testing.B) {
+	strategy := sockpair.SortFirstPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(append(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
-		true,
-	))
+		false,
+	), sockpair.Sock{Color: "pink", Pattern: "plain", Side: sockpair.Left}), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func TestSortFirstPairingStrategy_pairSocks(t *testing.T) {
-	for _, tt := range getTestCases() {
-		t.Run(tt.name, func(t *testing.T) {
-			strategy := SortFirstPairingStrategy{}
-			gotPairs, gotOrphans := strategy.pairSocks(tt.freshSocks)
-			// sort the returned values, so they can be compared to our shared test values
-			sort.Sort(gotPairs)
-			sort.Sort(gotOrphans)
-
-			if !reflect.DeepEqual(gotPairs, tt.wantPairs) {
-				t.Errorf("SortFirstPairingStrategy.pairSocks() pairs = %v, want %v", gotPairs, tt.wantPairs)
-			}
+func BenchmarkSortFirstPairingStrategy_PairSocks_allOrphans(b *testing.B) {
+	strategy := sockpair.SortFirstPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		10,
+		true,
+	), newRand(b))
+	for i := 0; i < b.N; i++ {
+		strategy.PairSocks(testSocks)
+	}
+}
 
-			if !reflect.DeepEqual(gotOrphans, tt.wantOrphans) {
-				t.Errorf("SortFirstPairingStrategy.pairSocks() orphans = %v, want %v", gotOrphans, tt.wantOrphans)
-			}
-		})
+func BenchmarkSortFirstPairingStrategy_PairSocks_oneKind(b *testing.B) {
+	strategy := sockpair.SortFirstPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"black"},
+		[]string{"plain"},
+		10000,
+		false,
+	), newRand(b))
+	for i := 0; i < b.N; i++ {
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkSortFirstPairingStrategy_pairSocks_noOrphans(b *testing.B) {
-	strategy := SortFirstPairingStrategy{}
-	testSocks := ShuffleSocks(GenerateSocks(
+func TestSurfacePairingStrategy_PairSocks(t *testing.T) {
+	sockpairtest.TestStrategy(t, sockpair.SurfacePairingStrategy{})
+}
+
+func BenchmarkSurfacePairingStrategy_PairSocks_noOrphans(b *testing.B) {
+	strategy := sockpair.SurfacePairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		false,
-	))
+	), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkSortFirstPairingStrategy_pairSocks_singleOrphan(b *testing.B) {
-	strategy := SortFirstPairingStrategy{}
-	testSocks := ShuffleSocks(append(GenerateSocks(
+func BenchmarkSurfacePairingStrategy_PairSocks_singleOrphan(b *testing.B) {
+	strategy := sockpair.SurfacePairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(append(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		false,
-	), Sock{"pink", "plain", true}))
+	), sockpair.Sock{Color: "pink", Pattern: "plain", Side: sockpair.Left}), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkSortFirstPairingStrategy_pairSocks_allOrphans(b *testing.B) {
-	strategy := SortFirstPairingStrategy{}
-	testSocks := ShuffleSocks(GenerateSocks(
+func BenchmarkSurfacePairingStrategy_PairSocks_allOrphans(b *testing.B) {
+	strategy := sockpair.SurfacePairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		true,
-	))
+	), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func TestSurfacePairingStrategy_pairSocks(t *testing.T) {
-	for _, tt := range getTestCases() {
-		t.Run(tt.name, func(t *testing.T) {
-			strategy := SurfacePairingStrategy{}
-			gotPairs, gotOrphans := strategy.pairSocks(tt.freshSocks)
-			// sort the returned values, so they can be compared to our shared test values
-			sort.Sort(gotPairs)
-			sort.Sort(gotOrphans)
-
-			if !reflect.DeepEqual(gotPairs, tt.wantPairs) {
-				t.Errorf("SurfacePairingStrategy.pairSocks() pairs = %v, want %v", gotPairs, tt.wantPairs)
-			}
+func BenchmarkSurfacePairingStrategy_PairSocks_oneKind(b *testing.B) {
+	strategy := sockpair.SurfacePairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
+		[]string{"black"},
+		[]string{"plain"},
+		10000,
+		false,
+	), newRand(b))
+	for i := 0; i < b.N; i++ {
+		strategy.PairSocks(testSocks)
+	}
+}
 
-			if !reflect.DeepEqual(gotOrphans, tt.wantOrphans) {
-				t.Errorf("SurfacePairingStrategy.pairSocks() orphans = %v, want %v", gotOrphans, tt.wantOrphans)
-			}
-		})
+func TestBucketPairingStrategy_PairSocks(t *testing.T) {
+	// RandomPairingStrategy is left out, as it gives up on socks after too many unlucky draws
+	inners := []sockpair.SockPairingStrategy{
+		nil,
+		sockpair.SortFirstPairingStrategy{},
+		sockpair.SurfacePairingStrategy{},
+		sockpair.MaximumMatchingPairingStrategy{},
+	}
+	for _, inner := range inners {
+		for _, by := range []sockpair.SockAttribute{0, sockpair.PatternAttribute, sockpair.ColorAttribute | sockpair.PatternAttribute} {
+			t.Run(fmt.Sprintf("%T/%d", inner, by), func(t *testing.T) {
+				sockpairtest.TestStrategy(t, sockpair.BucketPairingStrategy{By: by, Inner: inner})
+			})
+		}
+	}
+}
+
+func TestBucketPairingStrategy_PairSocks_stats(t *testing.T) {
+	// red, green and blue piles of two socks each, paired by comparing each pair once
+	got := sockpair.BucketPairingStrategy{}.PairSocks(sockpairtest.TestCases()[0].FreshSocks).Stats
+	want := sockpair.PairingStats{Comparisons: 3, Draws: 12, Removals: 3, SurfacePeak: 6, Placements: 6, Steps: 9}
+	if got != want {
+		t.Errorf("BucketPairingStrategy.PairSocks() stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestBucketPairingStrategy_PairSocks_owners(t *testing.T) {
+	// socks only pair within the pile of their owner
+	basket := sockpair.Socks{
+		{Color: "red", Side: sockpair.Left, Owner: "sam"},
+		{Color: "blue", Side: sockpair.Right, Owner: "alex"},
+		{Color: "red", Side: sockpair.Right, Owner: "sam"},
+		{Color: "blue", Side: sockpair.Left, Owner: "sam"},
+	}
+	got := sockpair.BucketPairingStrategy{By: sockpair.OwnerAttribute}.PairSocks(basket)
+	if len(got.Pairs) != 1 || len(got.Orphans) != 2 {
+		t.Errorf("BucketPairingStrategy.PairSocks() = %v, %v, want 1 pair and 2 orphans", got.Pairs, got.Orphans)
 	}
 }
 
-func BenchmarkSurfacePairingStrategy_pairSocks_noOrphans(b *testing.B) {
-	strategy := SurfacePairingStrategy{}
-	testSocks := ShuffleSocks(GenerateSocks(
+func BenchmarkBucketPairingStrategy_PairSocks_noOrphans(b *testing.B) {
+	strategy := sockpair.BucketPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		false,
-	))
+	), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkSurfacePairingStrategy_pairSocks_singleOrphan(b *testing.B) {
-	strategy := SurfacePairingStrategy{}
-	testSocks := ShuffleSocks(append(GenerateSocks(
+func BenchmarkBucketPairingStrategy_PairSocks_singleOrphan(b *testing.B) {
+	strategy := sockpair.BucketPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(append(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		false,
-	), Sock{"pink", "plain", true}))
+	), sockpair.Sock{Color: "pink", Pattern: "plain", Side: sockpair.Left}), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
 
-func BenchmarkSurfacePairingStrategy_pairSocks_allOrphans(b *testing.B) {
-	strategy := SurfacePairingStrategy{}
-	testSocks := ShuffleSocks(GenerateSocks(
+func BenchmarkBucketPairingStrategy_PairSocks_allOrphans(b *testing.B) {
+	strategy := sockpair.BucketPairingStrategy{}
+	testSocks := sockpair.ShuffleSocksWithRand(sockpair.GenerateSocks(
 		[]string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
 		[]string{"plain", "checkered", "herringbone", "plaid", "striped"},
 		10,
 		true,
-	))
+	), newRand(b))
 	for i := 0; i < b.N; i++ {
-		strategy.pairSocks(testSocks)
+		strategy.PairSocks(testSocks)
 	}
 }
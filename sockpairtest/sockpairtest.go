@@ -0,0 +1,168 @@
+// Package sockpairtest implements support for testing implementations of
+// sock_pair_in_golang.SockPairingStrategy, including strategies defined outside this module.
+package sockpairtest
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+)
+
+// TestCase is a basket of socks along with the pairs and orphans every strategy is expected
+// to find in it. WantPairs and WantOrphans are sorted.
+type TestCase struct {
+	Name        string
+	FreshSocks  sockpair.Socks
+	WantPairs   sockpair.SockPairs
+	WantOrphans sockpair.Socks
+}
+
+// mustParseSockPairs is like sockpair.MustParseSocks, for pairs.
+func mustParseSockPairs(text string) sockpair.SockPairs {
+	pairs, err := sockpair.ParseSockPairs(text)
+	if err != nil {
+		panic("sockpairtest: ParseSockPairs(" + text + "): " + err.Error())
+	}
+	return pairs
+}
+
+// TestCases returns the shared test cases every strategy must pass.
+// A fresh copy is returned on each call, so callers are free to modify it.
+func TestCases() []TestCase {
+	return []TestCase{
+		{
+			"3 matching pairs",
+			sockpair.MustParseSocks("red/plain/L, green/plain/L, red/plain/R, blue/plain/R, blue/plain/L, green/plain/R"),
+			mustParseSockPairs("blue/plain/L + blue/plain/R, green/plain/L + green/plain/R, red/plain/L + red/plain/R"),
+			make(sockpair.Socks, 0),
+		},
+		{
+			"3 matching pairs, 1 orphaned sock",
+			sockpair.MustParseSocks("red/plain/L, green/plain/L, red/plain/R, blue/plain/R, blue/plain/L, green/plain/R, pink/plain/L"),
+			mustParseSockPairs("blue/plain/L + blue/plain/R, green/plain/L + green/plain/R, red/plain/L + red/plain/R"),
+			sockpair.MustParseSocks("pink/plain/L"),
+		},
+		{
+			"all orphaned freshSocks",
+			sockpair.MustParseSocks("red/plain/L, green/plain/L, red/plain/L, blue/plain/L, blue/plain/L, green/plain/L, pink/plain/L"),
+			make(sockpair.SockPairs, 0),
+			sockpair.MustParseSocks("blue/plain/L x2, green/plain/L x2, pink/plain/L, red/plain/L x2"),
+		},
+		{
+			"no socks",
+			make(sockpair.Socks, 0),
+			make(sockpair.SockPairs, 0),
+			make(sockpair.Socks, 0),
+		},
+		{
+			"single sock",
+			sockpair.MustParseSocks("pink/plain/L"),
+			make(sockpair.SockPairs, 0),
+			sockpair.MustParseSocks("pink/plain/L"),
+		},
+		{
+			"socks that fit either foot",
+			sockpair.MustParseSocks("red/plain/E, blue/plain/L, red/plain/E, blue/plain/E, green/plain/R x2"),
+			mustParseSockPairs("blue/plain/L + blue/plain/E, red/plain/E + red/plain/E"),
+			sockpair.MustParseSocks("green/plain/R x2"),
+		},
+		{
+			"duplicate pairs",
+			sockpair.MustParseSocks("red/plain/R, red/plain/L x2, red/plain/R, red/plain/L, red/plain/R"),
+			mustParseSockPairs("red/plain/L + red/plain/R, red/plain/L + red/plain/R, red/plain/L + red/plain/R"),
+			make(sockpair.Socks, 0),
+		},
+		{
+			"duplicate socks that fit either foot",
+			sockpair.MustParseSocks("red/plain/L, red/plain/E, red/plain/L, red/plain/E"),
+			mustParseSockPairs("red/plain/L + red/plain/E, red/plain/L + red/plain/E"),
+			make(sockpair.Socks, 0),
+		},
+	}
+}
+
+// TestStrategy runs the strategy against every TestCase as a subtest of t, checking both the
+// pairs and orphans it finds and that it leaves the basket untouched. If the strategy implements
+// sockpair.ContextPairingStrategy, it is also checked to account for every Sock when cancelled.
+func TestStrategy(t *testing.T, strategy sockpair.SockPairingStrategy) {
+	if s, ok := strategy.(sockpair.ContextPairingStrategy); ok {
+		t.Run("cancelled", func(t *testing.T) {
+			testCancelled(t, s)
+		})
+	}
+
+	for _, tt := range TestCases() {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			freshSocks := append(sockpair.Socks{}, tt.FreshSocks...)
+			got := strategy.PairSocks(freshSocks)
+			if !equalSocks(freshSocks, tt.FreshSocks) {
+				t.Errorf("%T.PairSocks() modified basket to %v, want %v", strategy, freshSocks, tt.FreshSocks)
+			}
+
+			gotPairs, gotOrphans := got.Pairs, got.Orphans
+			// sort the returned values, so they can be compared to our shared test values
+			sort.Sort(gotPairs)
+			sort.Sort(gotOrphans)
+
+			if !equalPairs(gotPairs, tt.WantPairs) {
+				t.Errorf("%T.PairSocks() pairs = %v, want %v", strategy, gotPairs, tt.WantPairs)
+			}
+
+			if !equalSocks(gotOrphans, tt.WantOrphans) {
+				t.Errorf("%T.PairSocks() orphans = %v, want %v", strategy, gotOrphans, tt.WantOrphans)
+			}
+		})
+	}
+}
+
+// testCancelled runs the strategy against every TestCase with a cancelled context.
+func testCancelled(t *testing.T, strategy sockpair.ContextPairingStrategy) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, tt := range TestCases() {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			got, err := strategy.PairSocksContext(ctx, tt.FreshSocks)
+			if err != nil && err != ctx.Err() {
+				t.Errorf("%T.PairSocksContext() error = %v, want %v", strategy, err, ctx.Err())
+			}
+			if err == nil && len(got.Unprocessed) > 0 {
+				t.Errorf("%T.PairSocksContext() unprocessed = %v without an error", strategy, got.Unprocessed)
+			}
+
+			// every sock must still be accounted for exactly once
+			accounted := append(sockpair.Socks{}, got.Orphans...)
+			accounted = append(accounted, got.Unprocessed...)
+			for _, pair := range got.Pairs {
+				accounted = append(accounted, pair...)
+			}
+			want := append(sockpair.Socks{}, tt.FreshSocks...)
+			sort.Sort(accounted)
+			sort.Sort(want)
+			if !equalSocks(accounted, want) {
+				t.Errorf("%T.PairSocksContext() accounted for %v, want %v", strategy, accounted, want)
+			}
+		})
+	}
+}
+
+// equalPairs reports whether the pairs are deeply equal, treating nil and empty as equal.
+func equalPairs(got, want sockpair.SockPairs) bool {
+	if len(got) == 0 && len(want) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+// equalSocks reports whether the socks are deeply equal, treating nil and empty as equal.
+func equalSocks(got, want sockpair.Socks) bool {
+	if len(got) == 0 && len(want) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
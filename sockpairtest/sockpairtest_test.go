@@ -0,0 +1,44 @@
+package sockpairtest_test
+
+import (
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/sockpairtest"
+)
+
+// nestedLoopPairingStrategy is a strategy implemented outside the sock_pair_in_golang package,
+// comparing each Sock against every Sock after it.
+type nestedLoopPairingStrategy struct{}
+
+func (s nestedLoopPairingStrategy) PairSocks(freshSocks sockpair.Socks) sockpair.PairingResult {
+	pairedSocks := make(sockpair.SockPairs, 0)
+	orphanedSocks := make(sockpair.Socks, 0)
+	paired := make([]bool, len(freshSocks))
+
+	for i := range freshSocks {
+		if paired[i] {
+			continue
+		}
+		for j := i + 1; j < len(freshSocks); j++ {
+			if !paired[j] && freshSocks[i].IsMatchingPair(freshSocks[j]) {
+				if freshSocks[j].Side == sockpair.Left || freshSocks[i].Side == sockpair.Right {
+					pairedSocks = append(pairedSocks, sockpair.Socks{freshSocks[j], freshSocks[i]})
+				} else {
+					pairedSocks = append(pairedSocks, sockpair.Socks{freshSocks[i], freshSocks[j]})
+				}
+				paired[i], paired[j] = true, true
+				break
+			}
+		}
+		if !paired[i] {
+			orphanedSocks = append(orphanedSocks, freshSocks[i])
+		}
+	}
+
+	return sockpair.PairingResult{Pairs: pairedSocks, Orphans: orphanedSocks}
+}
+
+func TestTestStrategy(t *testing.T) {
+	sockpairtest.TestStrategy(t, nestedLoopPairingStrategy{})
+}
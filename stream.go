@@ -0,0 +1,64 @@
+package sock_pair_in_golang
+
+import "context"
+
+// PairStream pairs socks as they arrive on basket, laying each one out on a surface until its
+// matching Sock turns up, the same way SurfacePairingStrategy pairs a basket it can see all at once.
+// Each pair is sent on the returned pairs channel as soon as it is found.
+//
+// Once basket is closed, the pairs channel is closed and the socks left on the surface are sent
+// on the returned orphans channel before it is closed too, so callers may drain pairs and then
+// orphans. If ctx is cancelled first, both channels are closed without sending the orphans.
+func PairStream(ctx context.Context, basket <-chan Sock) (<-chan SockPair, <-chan Sock) {
+	pairs := make(chan SockPair)
+	orphans := make(chan Sock)
+
+	go func() {
+		defer close(orphans)
+		surface := newSurface(SockRules(), &PairingStats{})
+
+		finished := streamPairs(ctx, basket, pairs, surface)
+		close(pairs)
+		if !finished {
+			return
+		}
+
+		for _, sock := range surface.clear() {
+			select {
+			case orphans <- sock:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pairs, orphans
+}
+
+// streamPairs pairs the socks from basket on the surface, sending each pair as it is found.
+// It reports whether basket was drained before ctx was cancelled.
+func streamPairs(ctx context.Context, basket <-chan Sock, pairs chan<- SockPair, surface *surface[Sock, Sock]) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case sock, ok := <-basket:
+			if !ok {
+				return true
+			}
+
+			matchingSock, found := surface.take(sock)
+			if !found {
+				surface.place(sock)
+				continue
+			}
+
+			leftSock, rightSock := orderSockPair(sock, matchingSock)
+			select {
+			case pairs <- SockPair{leftSock, rightSock}:
+			case <-ctx.Done():
+				return false
+			}
+		}
+	}
+}
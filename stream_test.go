@@ -0,0 +1,87 @@
+package sock_pair_in_golang_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"github.com/burtawicz/sock-pair-in-golang/sockpairtest"
+)
+
+// feed sends each Sock on a new channel, closing it once every Sock has been sent.
+func feed(socks sockpair.Socks) <-chan sockpair.Sock {
+	basket := make(chan sockpair.Sock)
+	go func() {
+		defer close(basket)
+		for _, sock := range socks {
+			basket <- sock
+		}
+	}()
+	return basket
+}
+
+func TestPairStream(t *testing.T) {
+	for _, tt := range sockpairtest.TestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			pairs, orphans := sockpair.PairStream(context.Background(), feed(tt.FreshSocks))
+
+			gotPairs := make(sockpair.SockPairs, 0)
+			for pair := range pairs {
+				gotPairs = append(gotPairs, pair)
+			}
+			gotOrphans := make(sockpair.Socks, 0)
+			for sock := range orphans {
+				gotOrphans = append(gotOrphans, sock)
+			}
+			// sort the returned values, so they can be compared to our shared test values
+			sort.Sort(gotPairs)
+			sort.Sort(gotOrphans)
+
+			if !reflect.DeepEqual(gotPairs, tt.WantPairs) {
+				t.Errorf("PairStream() pairs = %v, want %v", gotPairs, tt.WantPairs)
+			}
+			if !reflect.DeepEqual(gotOrphans, tt.WantOrphans) {
+				t.Errorf("PairStream() orphans = %v, want %v", gotOrphans, tt.WantOrphans)
+			}
+		})
+	}
+}
+
+func TestPairStream_emitsPairsAsTheyForm(t *testing.T) {
+	basket := make(chan sockpair.Sock)
+	defer close(basket)
+	pairs, _ := sockpair.PairStream(context.Background(), basket)
+
+	leftSock := sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Left}
+	rightSock := sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Right}
+	basket <- rightSock
+	basket <- leftSock
+
+	select {
+	case got := <-pairs:
+		if want := (sockpair.SockPair{leftSock, rightSock}); !reflect.DeepEqual(got, want) {
+			t.Errorf("PairStream() pair = %v, want %v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("PairStream() did not emit a pair before the basket was closed")
+	}
+}
+
+func TestPairStream_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	basket := make(chan sockpair.Sock)
+	pairs, orphans := sockpair.PairStream(ctx, basket)
+
+	basket <- sockpair.Sock{Color: "red", Pattern: "plain", Side: sockpair.Left}
+	cancel()
+
+	for range pairs {
+		t.Errorf("PairStream() emitted a pair after being cancelled")
+	}
+	for sock := range orphans {
+		t.Errorf("PairStream() emitted orphan %v after being cancelled", sock)
+	}
+}
@@ -0,0 +1,136 @@
+package sock_pair_in_golang
+
+// pile holds items sharing a match key. When the rules give each item a Side, the items are
+// queued by side, so a partner is taken from the front of a queue whose side complements the
+// item's own rather than found by comparing the item against the whole pile. Items without a
+// side, or whose Side is not Left, Right or Either, are compared one by one.
+type pile[T any, K comparable] struct {
+	sides  [3][]T // indexed by Side
+	others []T
+	size   int
+}
+
+// sided returns the queue item belongs in, or nil if it must be compared with Complements.
+func (p *pile[T, K]) sided(rules Rules[T, K], item T) (*[]T, Side) {
+	if rules.Side == nil {
+		return nil, Either
+	}
+	side := rules.Side(item)
+	if side != Either && side != Left && side != Right {
+		return nil, side
+	}
+	return &p.sides[side], side
+}
+
+// add puts item on the pile.
+func (p *pile[T, K]) add(rules Rules[T, K], item T) {
+	if queue, _ := p.sided(rules, item); queue != nil {
+		*queue = append(*queue, item)
+	} else {
+		p.others = append(p.others, item)
+	}
+	p.size++
+}
+
+// take removes and returns an item from the pile that pairs with item, if there is one.
+func (p *pile[T, K]) take(rules Rules[T, K], item T, stats *PairingStats) (T, bool) {
+	if rules.Side != nil {
+		_, side := p.sided(rules, item)
+		for _, partner := range side.partners() {
+			if queue := p.sides[partner]; len(queue) > 0 {
+				stats.Comparisons++
+				p.sides[partner] = queue[1:]
+				p.size--
+				return queue[0], true
+			}
+		}
+	}
+
+	for i, candidate := range p.others {
+		stats.Comparisons++
+		if !rules.Complements(item, candidate) {
+			continue
+		}
+
+		if res, err := removeSockFromBasket(p.others, i); err == nil {
+			p.others = res
+			p.size--
+		}
+		return candidate, true
+	}
+
+	var none T
+	return none, false
+}
+
+// items returns every item on the pile.
+func (p *pile[T, K]) items() []T {
+	items := make([]T, 0, p.size)
+	for _, queue := range p.sides {
+		items = append(items, queue...)
+	}
+	return append(items, p.others...)
+}
+
+// surface is where items drawn from the basket are laid out, grouped into piles by match key,
+// until their matching item turns up.
+type surface[T any, K comparable] struct {
+	rules Rules[T, K]
+	stats *PairingStats
+	piles map[K]*pile[T, K]
+	size  int
+}
+
+// newSurface returns an empty surface that records the work done on it in stats.
+func newSurface[T any, K comparable](rules Rules[T, K], stats *PairingStats) *surface[T, K] {
+	return &surface[T, K]{rules: rules, stats: stats, piles: make(map[K]*pile[T, K])}
+}
+
+// take removes and returns an item from the surface that pairs with item, if there is one.
+func (s *surface[T, K]) take(item T) (T, bool) {
+	s.stats.Scans++
+	key := s.rules.Key(item)
+	p := s.piles[key]
+	if p == nil {
+		var none T
+		return none, false
+	}
+
+	matchingItem, ok := p.take(s.rules, item, s.stats)
+	if ok {
+		s.stats.Removals++
+		s.size--
+		if p.size == 0 {
+			delete(s.piles, key)
+		}
+	}
+	return matchingItem, ok
+}
+
+// place lays item out on the surface.
+func (s *surface[T, K]) place(item T) {
+	key := s.rules.Key(item)
+	p := s.piles[key]
+	if p == nil {
+		p = &pile[T, K]{}
+		s.piles[key] = p
+	}
+	p.add(s.rules, item)
+	s.stats.Placements++
+	s.size++
+	if s.size > s.stats.SurfacePeak {
+		s.stats.SurfacePeak = s.size
+	}
+}
+
+// clear removes and returns every item left on the surface.
+func (s *surface[T, K]) clear() []T {
+	remaining := make([]T, 0, s.size)
+	for _, p := range s.piles {
+		remaining = append(remaining, p.items()...)
+	}
+
+	s.piles = make(map[K]*pile[T, K])
+	s.size = 0
+	return remaining
+}
@@ -0,0 +1,44 @@
+package sock_pair_in_golang
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func Test_surface(t *testing.T) {
+	stats := PairingStats{}
+	s := newSurface(SockRules(), &stats)
+	if _, ok := s.take(Sock{Color: "red", Pattern: "plain", Side: Left}); ok {
+		t.Fatalf("surface.take() found a Sock on an empty surface")
+	}
+
+	s.place(Sock{Color: "red", Pattern: "plain", Side: Left})
+	s.place(Sock{Color: "red", Pattern: "plain", Side: Left})
+	s.place(Sock{Color: "blue", Pattern: "plain", Side: Right})
+	if s.size != 3 {
+		t.Errorf("surface.size = %d, want 3", s.size)
+	}
+
+	got, ok := s.take(Sock{Color: "red", Pattern: "plain", Side: Right})
+	if !ok || got != (Sock{Color: "red", Pattern: "plain", Side: Left}) {
+		t.Errorf("surface.take() = %v, %v, want %v, true", got, ok, Sock{Color: "red", Pattern: "plain", Side: Left})
+	}
+	if _, ok := s.take(Sock{Color: "blue", Pattern: "plain", Side: Right}); ok {
+		t.Errorf("surface.take() paired two right socks")
+	}
+
+	if want := (PairingStats{Comparisons: 1, Removals: 1, SurfacePeak: 3, Placements: 3, Scans: 3}); stats != want {
+		t.Errorf("surface stats = %+v, want %+v", stats, want)
+	}
+
+	remaining := Socks(s.clear())
+	sort.Sort(remaining)
+	want := Socks{Sock{Color: "blue", Pattern: "plain", Side: Right}, Sock{Color: "red", Pattern: "plain", Side: Left}}
+	if !reflect.DeepEqual(remaining, want) {
+		t.Errorf("surface.clear() = %v, want %v", remaining, want)
+	}
+	if s.size != 0 {
+		t.Errorf("surface.size after clear = %d, want 0", s.size)
+	}
+}
@@ -2,26 +2,75 @@ package sock_pair_in_golang
 
 import "math/rand"
 
+// Variety lists the values of each attribute to generate socks with. An attribute with no values
+// is left unspecified on every generated Sock.
+type Variety struct {
+	Colors    []string
+	Patterns  []string
+	Sizes     []string
+	Owners    []string
+	Materials []string
+	Brands    []string
+	Wears     []Wear
+}
+
+// GenerateSocks returns numDuplicates left and right pairs of every combination of color and
+// pattern, or only the left socks if onlySingles is set.
 func GenerateSocks(colors, patterns []string, numDuplicates int, onlySingles bool) Socks {
+	return GenerateVariedSocks(Variety{Colors: colors, Patterns: patterns}, numDuplicates, onlySingles)
+}
+
+// GenerateVariedSocks returns numDuplicates left and right pairs of every combination of the
+// attributes in v, or only the left socks if onlySingles is set.
+func GenerateVariedSocks(v Variety, numDuplicates int, onlySingles bool) Socks {
+	if onlySingles {
+		return generateSocks(v, numDuplicates, Left)
+	}
+	return generateSocks(v, numDuplicates, Left, Right)
+}
+
+// GenerateEitherSocks returns numDuplicates pairs of socks that fit either foot for every
+// combination of color and pattern, or only one sock of each pair if onlySingles is set.
+func GenerateEitherSocks(colors, patterns []string, numDuplicates int, onlySingles bool) Socks {
+	v := Variety{Colors: colors, Patterns: patterns}
+	if onlySingles {
+		return generateSocks(v, numDuplicates, Either)
+	}
+	return generateSocks(v, numDuplicates, Either, Either)
+}
+
+// generateSocks returns a Sock for each of the sides, numDuplicates times over, for every
+// combination of the attributes in v.
+func generateSocks(v Variety, numDuplicates int, sides ...Side) Socks {
 	socks := make(Socks, 0)
 	if numDuplicates < 1 {
 		return socks
 	}
 
-	for _, pattern := range patterns {
-		for _, color := range colors {
-			for i := 0; i < numDuplicates; i++ {
-				if onlySingles {
-					socks = append(
-						socks,
-						Sock{color, pattern, true},
-					)
-				} else {
-					socks = append(
-						socks,
-						Sock{color, pattern, true},
-						Sock{color, pattern, false},
-					)
+	for _, pattern := range v.Patterns {
+		for _, color := range v.Colors {
+			for _, size := range orUnspecified(v.Sizes) {
+				for _, owner := range orUnspecified(v.Owners) {
+					for _, material := range orUnspecified(v.Materials) {
+						for _, brand := range orUnspecified(v.Brands) {
+							for _, wear := range orUnspecified(v.Wears) {
+								for i := 0; i < numDuplicates; i++ {
+									for _, side := range sides {
+										socks = append(socks, Sock{
+											Color:    color,
+											Pattern:  pattern,
+											Side:     side,
+											Size:     size,
+											Owner:    owner,
+											Material: material,
+											Brand:    brand,
+											Wear:     wear,
+										})
+									}
+								}
+							}
+						}
+					}
 				}
 			}
 		}
@@ -30,10 +79,30 @@ func GenerateSocks(colors, patterns []string, numDuplicates int, onlySingles boo
 	return socks
 }
 
+// orUnspecified returns the values, or the zero value alone if there are none.
+func orUnspecified[T any](values []T) []T {
+	if len(values) == 0 {
+		return make([]T, 1)
+	}
+	return values
+}
+
+// ShuffleSocks shuffles the socks in place using the shared source from the math/rand package.
 func ShuffleSocks(socks Socks) Socks {
-	rand.Shuffle(len(socks), func(i, j int) {
+	return ShuffleSocksWithRand(socks, nil)
+}
+
+// ShuffleSocksWithRand shuffles the socks in place using r, so the shuffle can be reproduced
+// from the seed of r. A nil r uses the shared source from the math/rand package.
+func ShuffleSocksWithRand(socks Socks, r *rand.Rand) Socks {
+	swap := func(i, j int) {
 		socks[i], socks[j] = socks[j], socks[i]
-	})
+	}
+	if r == nil {
+		rand.Shuffle(len(socks), swap)
+	} else {
+		r.Shuffle(len(socks), swap)
+	}
 
 	return socks
 }
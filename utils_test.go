@@ -1,7 +1,9 @@
 package sock_pair_in_golang
 
 import (
+	"math/rand"
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -24,24 +26,24 @@ func TestGenerateSocks_pairs(t *testing.T) {
 				1,
 			},
 			Socks{
-				Sock{"red", "plain", true},
-				Sock{"red", "plain", false},
-				Sock{"blue", "plain", true},
-				Sock{"blue", "plain", false},
-				Sock{"green", "plain", true},
-				Sock{"green", "plain", false},
-				Sock{"red", "checkered", true},
-				Sock{"red", "checkered", false},
-				Sock{"blue", "checkered", true},
-				Sock{"blue", "checkered", false},
-				Sock{"green", "checkered", true},
-				Sock{"green", "checkered", false},
-				Sock{"red", "herringbone", true},
-				Sock{"red", "herringbone", false},
-				Sock{"blue", "herringbone", true},
-				Sock{"blue", "herringbone", false},
-				Sock{"green", "herringbone", true},
-				Sock{"green", "herringbone", false},
+				Sock{Color: "red", Pattern: "plain", Side: Left},
+				Sock{Color: "red", Pattern: "plain", Side: Right},
+				Sock{Color: "blue", Pattern: "plain", Side: Left},
+				Sock{Color: "blue", Pattern: "plain", Side: Right},
+				Sock{Color: "green", Pattern: "plain", Side: Left},
+				Sock{Color: "green", Pattern: "plain", Side: Right},
+				Sock{Color: "red", Pattern: "checkered", Side: Left},
+				Sock{Color: "red", Pattern: "checkered", Side: Right},
+				Sock{Color: "blue", Pattern: "checkered", Side: Left},
+				Sock{Color: "blue", Pattern: "checkered", Side: Right},
+				Sock{Color: "green", Pattern: "checkered", Side: Left},
+				Sock{Color: "green", Pattern: "checkered", Side: Right},
+				Sock{Color: "red", Pattern: "herringbone", Side: Left},
+				Sock{Color: "red", Pattern: "herringbone", Side: Right},
+				Sock{Color: "blue", Pattern: "herringbone", Side: Left},
+				Sock{Color: "blue", Pattern: "herringbone", Side: Right},
+				Sock{Color: "green", Pattern: "herringbone", Side: Left},
+				Sock{Color: "green", Pattern: "herringbone", Side: Right},
 			},
 		},
 		{
@@ -82,15 +84,15 @@ func TestGenerateSocks_singles(t *testing.T) {
 				1,
 			},
 			Socks{
-				Sock{"red", "plain", true},
-				Sock{"blue", "plain", true},
-				Sock{"green", "plain", true},
-				Sock{"red", "checkered", true},
-				Sock{"blue", "checkered", true},
-				Sock{"green", "checkered", true},
-				Sock{"red", "herringbone", true},
-				Sock{"blue", "herringbone", true},
-				Sock{"green", "herringbone", true},
+				Sock{Color: "red", Pattern: "plain", Side: Left},
+				Sock{Color: "blue", Pattern: "plain", Side: Left},
+				Sock{Color: "green", Pattern: "plain", Side: Left},
+				Sock{Color: "red", Pattern: "checkered", Side: Left},
+				Sock{Color: "blue", Pattern: "checkered", Side: Left},
+				Sock{Color: "green", Pattern: "checkered", Side: Left},
+				Sock{Color: "red", Pattern: "herringbone", Side: Left},
+				Sock{Color: "blue", Pattern: "herringbone", Side: Left},
+				Sock{Color: "green", Pattern: "herringbone", Side: Left},
 			},
 		},
 		{
@@ -111,3 +113,67 @@ func TestGenerateSocks_singles(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateEitherSocks(t *testing.T) {
+	got := GenerateEitherSocks([]string{"red", "blue"}, []string{"plain"}, 1, false)
+	want := Socks{
+		Sock{Color: "red", Pattern: "plain", Side: Either},
+		Sock{Color: "red", Pattern: "plain", Side: Either},
+		Sock{Color: "blue", Pattern: "plain", Side: Either},
+		Sock{Color: "blue", Pattern: "plain", Side: Either},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateEitherSocks() = %v, want %v", got, want)
+	}
+
+	got = GenerateEitherSocks([]string{"red", "blue"}, []string{"plain"}, 1, true)
+	want = Socks{Sock{Color: "red", Pattern: "plain", Side: Either}, Sock{Color: "blue", Pattern: "plain", Side: Either}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateEitherSocks() singles = %v, want %v", got, want)
+	}
+}
+
+func TestGenerateVariedSocks(t *testing.T) {
+	v := Variety{
+		Colors:   []string{"red"},
+		Patterns: []string{"plain"},
+		Sizes:    []string{"child", "adult"},
+		Owners:   []string{"sam"},
+		Wears:    []Wear{Worn},
+	}
+	got := GenerateVariedSocks(v, 1, false)
+	want := Socks{
+		Sock{Color: "red", Pattern: "plain", Side: Left, Size: "child", Owner: "sam", Wear: Worn},
+		Sock{Color: "red", Pattern: "plain", Side: Right, Size: "child", Owner: "sam", Wear: Worn},
+		Sock{Color: "red", Pattern: "plain", Side: Left, Size: "adult", Owner: "sam", Wear: Worn},
+		Sock{Color: "red", Pattern: "plain", Side: Right, Size: "adult", Owner: "sam", Wear: Worn},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateVariedSocks() = %v, want %v", got, want)
+	}
+
+	got = GenerateVariedSocks(v, 1, true)
+	want = Socks{
+		Sock{Color: "red", Pattern: "plain", Side: Left, Size: "child", Owner: "sam", Wear: Worn},
+		Sock{Color: "red", Pattern: "plain", Side: Left, Size: "adult", Owner: "sam", Wear: Worn},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateVariedSocks() singles = %v, want %v", got, want)
+	}
+}
+
+func TestShuffleSocksWithRand(t *testing.T) {
+	socks := GenerateSocks([]string{"red", "blue", "green"}, []string{"plain", "checkered"}, 2, false)
+	first := ShuffleSocksWithRand(append(Socks{}, socks...), rand.New(rand.NewSource(7)))
+	second := ShuffleSocksWithRand(append(Socks{}, socks...), rand.New(rand.NewSource(7)))
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("ShuffleSocksWithRand() with the same seed = %v, then %v", first, second)
+	}
+
+	// a shuffle must be a permutation of the original socks
+	sort.Sort(first)
+	sort.Sort(socks)
+	if !reflect.DeepEqual(first, socks) {
+		t.Errorf("ShuffleSocksWithRand() sorted = %v, want %v", first, socks)
+	}
+}
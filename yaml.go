@@ -0,0 +1,185 @@
+package sock_pair_in_golang
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML writes s as a mapping with the same fields as its JSON encoding.
+func (s Sock) MarshalYAML() (interface{}, error) {
+	return newEncodedSock(s), nil
+}
+
+func (s *Sock) UnmarshalYAML(value *yaml.Node) error {
+	var es encodedSock
+	if err := value.Decode(&es); err != nil {
+		return err
+	}
+	*s = es.sock()
+	return nil
+}
+
+// UnmarshalYAML parses pairs written as a sequence of two Sock sequences, as SockPairs are
+// written by default, returning an error if a pair does not hold two socks.
+func (p *SockPairs) UnmarshalYAML(value *yaml.Node) error {
+	var pairs []Socks
+	if err := value.Decode(&pairs); err != nil {
+		return err
+	}
+	return p.set(pairs)
+}
+
+// YAMLSockReader reads socks one at a time from a YAML block sequence, as written by yaml.Marshal
+// or YAMLSockWriter, in which each Sock starts on a new line with "- ". Any other YAML document,
+// such as a flow sequence or an indented block sequence, is read whole once it is reached. A
+// stream of several documents, each a sequence, is read as one basket.
+type YAMLSockReader struct {
+	lines *bufio.Scanner
+	line  int
+	// next is a line read that starts the next Sock.
+	next    string
+	hasNext bool
+	// socks are the socks left to return from a document read whole.
+	socks Socks
+	whole bool
+}
+
+func NewYAMLSockReader(r io.Reader) *YAMLSockReader {
+	return &YAMLSockReader{lines: bufio.NewScanner(r)}
+}
+
+func (r *YAMLSockReader) Read() (Sock, error) {
+	if r.whole {
+		return r.readWhole()
+	}
+
+	item := make([]string, 0)
+	start := 0
+	skipped := make([]string, 0)
+	for {
+		line, ok := r.nextLine()
+		if !ok {
+			break
+		}
+		startsItem := line == "-" || strings.HasPrefix(line, "- ")
+		if len(item) > 0 {
+			if startsItem || isDocumentMarker(line) {
+				r.next, r.hasNext = line, true
+				break
+			}
+			item = append(item, line)
+			continue
+		}
+
+		switch trimmed := strings.TrimSpace(line); {
+		case startsItem:
+			item = append(item, line)
+			start = r.line
+		case trimmed == "" || isDocumentMarker(line) || trimmed == "[]" || strings.HasPrefix(trimmed, "#"):
+			// nothing to read before the first Sock
+			skipped = append(skipped, line)
+		default:
+			// the document is not a top-level block sequence, so can only be read whole
+			return r.readDocument(append(skipped, line))
+		}
+	}
+	if err := r.lines.Err(); err != nil {
+		return Sock{}, err
+	}
+	if len(item) == 0 {
+		return Sock{}, io.EOF
+	}
+
+	var socks Socks
+	if err := yaml.Unmarshal([]byte(strings.Join(item, "\n")), &socks); err != nil {
+		return Sock{}, fmt.Errorf("sock at line %d: %w", start, err)
+	}
+	if len(socks) != 1 {
+		return Sock{}, fmt.Errorf("sock at line %d: want one sock, got %d", start, len(socks))
+	}
+	return socks[0], nil
+}
+
+// isDocumentMarker reports whether line starts or ends a YAML document.
+func isDocumentMarker(line string) bool {
+	return line == "---" || strings.HasPrefix(line, "--- ") || line == "..."
+}
+
+// readDocument reads the rest of the stream after the lines already read, and decodes each of
+// its documents whole.
+func (r *YAMLSockReader) readDocument(lines []string) (Sock, error) {
+	for r.lines.Scan() {
+		lines = append(lines, r.lines.Text())
+	}
+	if err := r.lines.Err(); err != nil {
+		return Sock{}, err
+	}
+	dec := yaml.NewDecoder(strings.NewReader(strings.Join(lines, "\n")))
+	for {
+		var socks Socks
+		err := dec.Decode(&socks)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Sock{}, fmt.Errorf("socks must be written as a YAML sequence: %w", err)
+		}
+		r.socks = append(r.socks, socks...)
+	}
+	r.whole = true
+	return r.readWhole()
+}
+
+func (r *YAMLSockReader) readWhole() (Sock, error) {
+	if len(r.socks) == 0 {
+		return Sock{}, io.EOF
+	}
+	s := r.socks[0]
+	r.socks = r.socks[1:]
+	return s, nil
+}
+
+func (r *YAMLSockReader) nextLine() (string, bool) {
+	if r.hasNext {
+		r.hasNext = false
+		return r.next, true
+	}
+	if !r.lines.Scan() {
+		return "", false
+	}
+	r.line++
+	return r.lines.Text(), true
+}
+
+// YAMLSockWriter writes socks one at a time as a YAML block sequence.
+type YAMLSockWriter struct {
+	w     io.Writer
+	count int
+}
+
+func NewYAMLSockWriter(w io.Writer) *YAMLSockWriter {
+	return &YAMLSockWriter{w: w}
+}
+
+func (w *YAMLSockWriter) Write(s Sock) error {
+	data, err := yaml.Marshal(Socks{s})
+	if err != nil {
+		return err
+	}
+	w.count++
+	_, err = w.w.Write(data)
+	return err
+}
+
+// Close writes an empty sequence if no Sock was written.
+func (w *YAMLSockWriter) Close() error {
+	if w.count > 0 {
+		return nil
+	}
+	_, err := io.WriteString(w.w, "[]\n")
+	return err
+}
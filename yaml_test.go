@@ -0,0 +1,172 @@
+package sock_pair_in_golang_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	sockpair "github.com/burtawicz/sock-pair-in-golang"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSock_MarshalYAML(t *testing.T) {
+	data, err := yaml.Marshal(encodingBasket[2])
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	want := `id: rfid-0042
+color: navy
+pattern: ribbed, with a heel
+side: right
+shade: {l: 13, a: 47.5, b: -64.5}
+size: child
+owner: sam
+material: wool
+brand: acme
+wear: worn out
+`
+	if string(data) != want {
+		t.Errorf("yaml.Marshal() = %s, want %s", data, want)
+	}
+
+	var got sockpair.Sock
+	if err := yaml.Unmarshal([]byte("color: red\nside: sideways\n"), &got); err == nil {
+		t.Errorf("yaml.Unmarshal() of an invalid side returned no error")
+	}
+}
+
+func TestSocks_MarshalYAML(t *testing.T) {
+	data, err := yaml.Marshal(encodingBasket)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	var got sockpair.Socks
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, encodingBasket) {
+		t.Errorf("yaml.Unmarshal() = %v, want %v", got, encodingBasket)
+	}
+}
+
+func TestSockPairs_MarshalYAML(t *testing.T) {
+	data, err := yaml.Marshal(encodingPairs)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	var got sockpair.SockPairs
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, encodingPairs) {
+		t.Errorf("yaml.Unmarshal() = %v, want %v", got, encodingPairs)
+	}
+
+	if err := yaml.Unmarshal([]byte("- - color: red\n"), &got); err == nil {
+		t.Errorf("yaml.Unmarshal() of a pair with one sock returned no error")
+	}
+}
+
+func TestYAMLSockWriter(t *testing.T) {
+	basket := sockpair.AssignIDs(sockpair.GenerateVariedSocks(sockpair.Variety{
+		Colors:   []string{"red", "orange", "yellow", "green", "blue", "indigo", "violet"},
+		Patterns: []string{"plain", "checkered", "herringbone", "plaid", "striped"},
+		Brands:   []string{"acme", "generic"},
+	}, 3, false), "sock")
+
+	for _, socks := range []sockpair.Socks{basket, encodingBasket, {}} {
+		var b bytes.Buffer
+		if err := sockpair.WriteSocks(sockpair.NewYAMLSockWriter(&b), socks); err != nil {
+			t.Fatalf("WriteSocks() error = %v", err)
+		}
+
+		// what is written streaming is read back whole, and the other way around
+		var whole sockpair.Socks
+		if err := yaml.Unmarshal(b.Bytes(), &whole); err != nil {
+			t.Fatalf("yaml.Unmarshal() error = %v", err)
+		}
+		if !reflect.DeepEqual(whole, socks) {
+			t.Errorf("yaml.Unmarshal() = %v, want %v", whole, socks)
+		}
+		data, err := yaml.Marshal(socks)
+		if err != nil {
+			t.Fatalf("yaml.Marshal() error = %v", err)
+		}
+		streamed, err := sockpair.ReadSocks(sockpair.NewYAMLSockReader(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("ReadSocks() error = %v", err)
+		}
+		if !reflect.DeepEqual(streamed, socks) {
+			t.Errorf("ReadSocks() = %v, want %v", streamed, socks)
+		}
+	}
+}
+
+func TestYAMLSockReader(t *testing.T) {
+	data := `# this week's basket
+---
+- color: red
+  side: left
+
+# the other one
+- {color: red, side: right}
+-
+  color: blue
+  side: left
+`
+	got, err := sockpair.ReadSocks(sockpair.NewYAMLSockReader(strings.NewReader(data)))
+	if err != nil {
+		t.Fatalf("ReadSocks() error = %v", err)
+	}
+	want := sockpair.Socks{
+		{Color: "red", Side: sockpair.Left},
+		{Color: "red", Side: sockpair.Right},
+		{Color: "blue", Side: sockpair.Left},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadSocks() = %v, want %v", got, want)
+	}
+
+	for _, data := range []string{
+		"color: red\n",
+		"- color: red\n- side: up\n",
+		"[{color: red}, {side: up}]\n",
+		"[{color: red}\n",
+		"- color: red\n  side: left\n---\ncolor: blue\n",
+		"[{color: red}]\n---\ncolor: blue\n",
+	} {
+		if _, err := sockpair.ReadSocks(sockpair.NewYAMLSockReader(strings.NewReader(data))); err == nil {
+			t.Errorf("ReadSocks(%q) returned no error", data)
+		}
+	}
+}
+
+func TestYAMLSockReader_wholeDocument(t *testing.T) {
+	want := sockpair.Socks{
+		{Color: "red", Side: sockpair.Left},
+		{Color: "red", Side: sockpair.Right},
+	}
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "flow sequence", data: "[{color: red, side: left}, {color: red, side: right}]\n"},
+		{name: "flow sequence over several lines", data: "# a basket\n[\n  {color: red, side: left},\n  {color: red, side: right},\n]\n"},
+		{name: "indented block sequence", data: "  - color: red\n    side: left\n  - color: red\n    side: right\n"},
+		{name: "block sequences in several documents", data: "- color: red\n  side: left\n---\n- color: red\n  side: right\n...\n"},
+		{name: "flow sequences in several documents", data: "[{color: red, side: left}]\n--- [{color: red, side: right}]\n"},
+		{name: "block then flow sequence", data: "---\n- color: red\n  side: left\n---\n[{color: red, side: right}]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sockpair.ReadSocks(sockpair.NewYAMLSockReader(strings.NewReader(tt.data)))
+			if err != nil {
+				t.Fatalf("ReadSocks() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ReadSocks() = %v, want %v", got, want)
+			}
+		})
+	}
+}